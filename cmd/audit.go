@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"mantle/pkg/audit"
+	"mantle/pkg/bundle"
+	"mantle/pkg/lint"
+	"mantle/pkg/policy"
+	"mantle/pkg/report"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditLevel    string
+	auditOutput   string
+	auditPolicies []string
+)
+
+var auditCmd = &cobra.Command{
+	Use:   "audit [files...]",
+	Short: "checks pod templates against the pod security standards",
+	Long: `Checks the pod template of every pod and workload in the given files
+against a pod security standard (privileged, baseline or restricted),
+and against the policies in any --policy files. Reads from stdin if no
+files are given. Exits with an error if any violations are found.`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		level, err := audit.ParseLevel(auditLevel)
+		if err != nil {
+			return err
+		}
+
+		policies, err := policy.Load(auditPolicies...)
+		if err != nil {
+			return err
+		}
+
+		b, err := bundle.Load(bundlePaths(args))
+		if err != nil {
+			return err
+		}
+
+		findings, err := audit.Bundle(b, level)
+		if err != nil {
+			return err
+		}
+
+		policyFindings, err := (&lint.Linter{Rules: policy.Rules(policies)}).Bundle(b)
+		if err != nil {
+			return err
+		}
+		findings = append(findings, policyFindings...)
+
+		if err := report.Print(os.Stdout, findings, auditOutput); err != nil {
+			return err
+		}
+
+		if n := report.Count(findings, report.SeverityError); n > 0 {
+			return fmt.Errorf("%d violations at pod security level %s", n, level)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	auditCmd.Flags().StringVarP(&auditLevel, "level", "l", string(audit.LevelRestricted), "pod security standard: privileged, baseline or restricted")
+	auditCmd.Flags().StringVarP(&auditOutput, "output", "o", report.FormatText, "output format: text or json")
+	auditCmd.Flags().StringSliceVarP(&auditPolicies, "policy", "p", nil, "policy files to check as well")
+	RootCmd.AddCommand(auditCmd)
+}
+
+// bundlePaths returns the files to load a bundle from,
+// defaulting to stdin when no files are given
+func bundlePaths(args []string) []string {
+	if len(args) == 0 {
+		return []string{bundle.Stdin}
+	}
+	return args
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"mantle/pkg/fieldmap"
+	"mantle/pkg/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain path",
+	Short: "describes a mantle field and the kubernetes fields it maps to",
+	Long: `Describes the mantle fields matching a path: their type, allowed
+values or shorthand forms, defaults, and the kubernetes fields they're
+converted to. Paths match ignoring case and plurals, may leave out
+indices, and may start at any depth, e.g.
+
+  mantle explain pod.containers.cpu
+  mantle explain cpu.min
+  mantle explain volume.pvc`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		fields := fieldmap.Find(args[0])
+		if len(fields) == 0 {
+			return fmt.Errorf("no mantle field matches %s", args[0])
+		}
+
+		s, err := schema.Generate()
+		if err != nil {
+			return err
+		}
+		index := schema.NewIndex(s)
+
+		for i, field := range fields {
+			if i > 0 {
+				fmt.Println()
+			}
+			if err := fieldmap.Explain(os.Stdout, field, index); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(explainCmd)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/sourcemap"
+
+	"github.com/spf13/cobra"
+)
+
+var explainErrorFile string
+
+var explainErrorCmd = &cobra.Command{
+	Use:   "explain-error files...",
+	Short: "rewrites kubernetes errors about generated objects in mantle terms",
+	Long: `Rewrites the kubernetes field paths in an error from kubectl or the
+API server, e.g. spec.containers[0].resources.limits.cpu, as the mantle
+fields and lines of the given mantle files they were generated from,
+e.g. pod.containers[0].cpu.max (broker.yaml:12:7). Reads the error from
+stdin unless --error is given.
+
+  kubectl apply -f broker.json 2>&1 | mantle explain-error broker.yaml`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		b, err := bundle.Load(args)
+		if err != nil {
+			return err
+		}
+
+		_, sourceMap, err := sourcemap.ToKube(b)
+		if err != nil {
+			return err
+		}
+
+		var text []byte
+		if len(explainErrorFile) > 0 && explainErrorFile != bundle.Stdin {
+			text, err = ioutil.ReadFile(explainErrorFile)
+		} else {
+			text, err = ioutil.ReadAll(os.Stdin)
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(sourceMap.Rewrite(string(text)))
+		return nil
+	},
+}
+
+func init() {
+	explainErrorCmd.Flags().StringVarP(&explainErrorFile, "error", "e", "", "read the error from this file instead of stdin")
+	RootCmd.AddCommand(explainErrorCmd)
+}
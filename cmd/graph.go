@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/graph"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphOutput  string
+	graphOrphans bool
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph [files...]",
+	Short: "exports the dependency graph of a bundle",
+	Long: `Builds a graph of the objects in the given files: pods and workloads
+point at the ConfigMaps, Secrets, PersistentVolumeClaims, ServiceAccounts
+and PriorityClasses they use, Services and PodDisruptionBudgets point at
+the workloads they select, and HorizontalPodAutoscalers point at the
+workloads they scale. Referenced objects that aren't in the bundle are
+drawn dashed. Reads from stdin if no files are given.`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		b, err := bundle.Load(bundlePaths(args))
+		if err != nil {
+			return err
+		}
+
+		g, err := graph.Build(b)
+		if err != nil {
+			return err
+		}
+
+		if graphOrphans {
+			for _, orphan := range g.Orphans() {
+				fmt.Printf("%s: %s\n", orphan.Location, orphan.ID)
+			}
+			return nil
+		}
+
+		return g.Write(os.Stdout, graphOutput)
+	},
+}
+
+func init() {
+	graphCmd.Flags().StringVarP(&graphOutput, "output", "o", graph.FormatDOT, "output format: dot or json")
+	graphCmd.Flags().BoolVar(&graphOrphans, "orphans", false, "list the ConfigMaps, Secrets and PersistentVolumeClaims nothing uses")
+	RootCmd.AddCommand(graphCmd)
+}
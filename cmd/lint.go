@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/lint"
+	"mantle/pkg/policy"
+	"mantle/pkg/report"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintPolicies  []string
+	lintDisable   []string
+	lintOutput    string
+	lintListRules bool
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [files...]",
+	Short: "checks pod templates against best practices",
+	Long: `Checks the pod template of every pod and workload in the given files
+against mantle's best-practice rules. Reads from stdin if no files are
+given. Exits with an error if any error-level problems are found.
+
+Policies in --policy files are run as rules, named after the policy.
+
+Rules can be skipped for a single pod by listing them, separated by
+commas, in its ` + lint.DisableAnnotation + ` annotation.`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		policies, err := policy.Load(lintPolicies...)
+		if err != nil {
+			return err
+		}
+
+		linter := lint.NewLinter()
+		for _, rule := range policy.Rules(policies) {
+			linter.Register(rule)
+		}
+
+		if lintListRules {
+			return printLintRules(linter.Rules)
+		}
+
+		if err := linter.Disable(lintDisable...); err != nil {
+			return err
+		}
+
+		b, err := bundle.Load(bundlePaths(args))
+		if err != nil {
+			return err
+		}
+
+		findings, err := linter.Bundle(b)
+		if err != nil {
+			return err
+		}
+
+		if err := report.Print(os.Stdout, findings, lintOutput); err != nil {
+			return err
+		}
+
+		if n := report.Count(findings, report.SeverityError); n > 0 {
+			return fmt.Errorf("%d lint errors", n)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	lintCmd.Flags().StringSliceVarP(&lintPolicies, "policy", "p", nil, "policy files to run as rules")
+	lintCmd.Flags().StringSliceVarP(&lintDisable, "disable", "d", nil, "rules to skip")
+	lintCmd.Flags().StringVarP(&lintOutput, "output", "o", report.FormatText, "output format: text or json")
+	lintCmd.Flags().BoolVar(&lintListRules, "list-rules", false, "list the lint rules and exit")
+	RootCmd.AddCommand(lintCmd)
+}
+
+func printLintRules(rules []lint.Rule) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, rule := range rules {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", rule.Name(), rule.Severity(), rule.Description())
+	}
+	return w.Flush()
+}
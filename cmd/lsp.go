@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"os"
+
+	"mantle/pkg/lsp"
+
+	"github.com/spf13/cobra"
+)
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "runs a language server for mantle files",
+	Long: `Runs a Language Server Protocol server on stdin and stdout, for
+editors to use with mantle files. It completes field names and enum
+values, shows the kubernetes fields a mantle field is converted to on
+hover, reports decoding and validation errors as you type, and jumps
+from a volume mount or env var to the volume or ConfigMap it uses.`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		server, err := lsp.NewServer(os.Stdin, os.Stdout)
+		if err != nil {
+			return err
+		}
+		return server.Serve()
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(lspCmd)
+}
@@ -1,17 +1,24 @@
 package cmd
 
 import (
+	"mantle/pkg/codec"
 	"mantle/pkg/initialize"
 
 	"github.com/spf13/cobra"
 )
 
+var decodeOptions codec.DecodeOptions
+
 var RootCmd = &cobra.Command{
 	Use:           "pulsar",
 	Short:         "deploys and manages apache pulsar",
 	SilenceErrors: true,
 	SilenceUsage:  true,
 	RunE: func(_ *cobra.Command, args []string) error {
-		return initialize.MantleInit()
+		return initialize.MantleInit(decodeOptions)
 	},
 }
+
+func init() {
+	RootCmd.Flags().BoolVar(&decodeOptions.Clean, "clean", false, "drop the status, server-set metadata and server defaults of objects exported from a cluster")
+}
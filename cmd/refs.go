@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/references"
+	"mantle/pkg/report"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	refsAllow     []string
+	refsAllowlist string
+	refsOutput    string
+)
+
+var refsCmd = &cobra.Command{
+	Use:   "refs [files...]",
+	Short: "checks that the references made by pods resolve within the bundle",
+	Long: `Checks that the ConfigMaps, Secrets, PersistentVolumeClaims,
+ServiceAccounts and PriorityClasses referenced by every pod and workload
+in the given files are in the bundle or the allowlist, and that
+referenced ConfigMap and Secret keys exist. Reads from stdin if no
+files are given. Exits with an error if any required reference
+doesn't resolve.
+
+Allowlist entries are Kind/name or Kind/namespace/name,
+e.g. Secret/pulsar/registry-creds or PriorityClass/*`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		allowlist := references.Allowlist(refsAllow)
+		if len(refsAllowlist) > 0 {
+			fileAllowlist, err := references.LoadAllowlist(refsAllowlist)
+			if err != nil {
+				return err
+			}
+			allowlist = append(allowlist, fileAllowlist...)
+		}
+
+		b, err := bundle.Load(bundlePaths(args))
+		if err != nil {
+			return err
+		}
+
+		findings, err := references.Check(b, allowlist)
+		if err != nil {
+			return err
+		}
+
+		if err := report.Print(os.Stdout, findings, refsOutput); err != nil {
+			return err
+		}
+
+		if n := report.Count(findings, report.SeverityError); n > 0 {
+			return fmt.Errorf("%d unresolved references", n)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	refsCmd.Flags().StringSliceVarP(&refsAllow, "allow", "a", nil, "objects outside the bundle that may be referenced")
+	refsCmd.Flags().StringVar(&refsAllowlist, "allowlist", "", "file listing objects outside the bundle, one per line")
+	refsCmd.Flags().StringVarP(&refsOutput, "output", "o", report.FormatText, "output format: text or json")
+	RootCmd.AddCommand(refsCmd)
+}
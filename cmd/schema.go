@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"fmt"
+
+	"mantle/pkg/schema"
+
+	"github.com/spf13/cobra"
+)
+
+var schemaKind string
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "prints the JSON Schema of the mantle format",
+	Long: `Prints the JSON Schema (draft-07) of mantle documents, for editors
+and CI to validate mantle files against. With --kind, prints the schema
+of a single kind of object, without the kind envelope.`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		var s schema.Schema
+		var err error
+		if len(schemaKind) > 0 {
+			s, err = schema.GenerateKind(schemaKind)
+		} else {
+			s, err = schema.Generate()
+		}
+		if err != nil {
+			return err
+		}
+
+		data, err := schema.Marshal(s)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+func init() {
+	schemaCmd.Flags().StringVarP(&schemaKind, "kind", "k", "", "print the schema of a single kind, e.g. pod")
+	RootCmd.AddCommand(schemaCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"os"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/report"
+	"mantle/pkg/status"
+
+	"github.com/spf13/cobra"
+)
+
+var statusOutput string
+
+var statusCmd = &cobra.Command{
+	Use:   "status [files...]",
+	Short: "summarizes the status of pods and workloads",
+	Long: `Summarizes the status of the pods and workloads in the given files,
+e.g. as written by kubectl get -o yaml: the phase and readiness of every
+pod, the state, restarts and last termination of its containers, which
+containers are crash-looping, and which readiness gates aren't met.
+Reads from stdin if no files are given.`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		b, err := bundle.Load(bundlePaths(args))
+		if err != nil {
+			return err
+		}
+
+		summary, err := status.Bundle(b)
+		if err != nil {
+			return err
+		}
+
+		return summary.Print(os.Stdout, statusOutput)
+	},
+}
+
+func init() {
+	statusCmd.Flags().StringVarP(&statusOutput, "output", "o", report.FormatText, "output format: text or json")
+	RootCmd.AddCommand(statusCmd)
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/report"
+	"mantle/pkg/roundtrip"
+
+	"github.com/spf13/cobra"
+)
+
+var verifyRoundtripOutput string
+
+var verifyRoundtripCmd = &cobra.Command{
+	Use:   "verify-roundtrip [files...]",
+	Short: "checks what converting kubernetes objects to mantle loses",
+	Long: `Converts every kubernetes pod and config map in the given files to
+mantle and back, along with the pod template of every workload, and
+reports every field that was dropped, changed or added on the way.
+Reads from stdin if no files are given. Exits with an error if the
+round trip isn't lossless.`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		b, err := bundle.Load(bundlePaths(args))
+		if err != nil {
+			return err
+		}
+
+		findings, err := roundtrip.Bundle(b)
+		if err != nil {
+			return err
+		}
+
+		if err := report.Print(os.Stdout, findings, verifyRoundtripOutput); err != nil {
+			return err
+		}
+
+		if len(findings) > 0 {
+			return fmt.Errorf("%d differences after the round trip", len(findings))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	verifyRoundtripCmd.Flags().StringVarP(&verifyRoundtripOutput, "output", "o", report.FormatText, "output format: text or json")
+	RootCmd.AddCommand(verifyRoundtripCmd)
+}
@@ -0,0 +1,154 @@
+// Command enumgen writes the methods that make an int enum read and
+// write its names. It's run by go generate from a package's enums.go,
+// and reads the enum.Names variables declared there: xyzNames holds
+// the names of type Xyz. The methods are written to enums_generated.go.
+//
+//	//go:generate go run mantle/internal/enumgen
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const output = "enums_generated.go"
+
+var methods = template.Must(template.New("methods").Parse(`// Code generated by enumgen. DO NOT EDIT.
+
+package {{.Package}}
+{{range .Enums}}
+// EnumNames returns the names of the {{.Type}} values
+func ({{.Type}}) EnumNames() []string {
+	return {{.Names}}
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func ({{.Receiver}} {{.Type}}) MarshalText() ([]byte, error) {
+	return {{.Names}}.Text(int({{.Receiver}}))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func ({{.Receiver}} *{{.Type}}) UnmarshalText(text []byte) error {
+	value, err := {{.Names}}.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*{{.Receiver}} = {{.Type}}(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func ({{.Receiver}} *{{.Type}}) UnmarshalJSON(data []byte) error {
+	value, err := {{.Names}}.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*{{.Receiver}} = {{.Type}}(value)
+	return nil
+}
+{{end}}`))
+
+type enum struct {
+	Type     string
+	Names    string
+	Receiver string
+}
+
+func main() {
+	file := os.Getenv("GOFILE")
+	if len(file) == 0 {
+		fmt.Fprintln(os.Stderr, "enumgen: must be run by go generate")
+		os.Exit(1)
+	}
+
+	if err := generate(file); err != nil {
+		fmt.Fprintf(os.Stderr, "enumgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func generate(file string) error {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	enums := namesVars(f)
+	if len(enums) == 0 {
+		return fmt.Errorf("no enum.Names variables in %s", file)
+	}
+
+	var buf bytes.Buffer
+	err = methods.Execute(&buf, struct {
+		Package string
+		Enums   []enum
+	}{f.Name.Name, enums})
+	if err != nil {
+		return err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(output, src, 0644)
+}
+
+// namesVars returns the enums of the enum.Names variables in the file
+func namesVars(f *ast.File) []enum {
+	var enums []enum
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			for i, name := range spec.(*ast.ValueSpec).Names {
+				if !isNames(spec.(*ast.ValueSpec), i) || !strings.HasSuffix(name.Name, "Names") {
+					continue
+				}
+
+				typ := strings.TrimSuffix(name.Name, "Names")
+				typ = strings.ToUpper(typ[:1]) + typ[1:]
+				enums = append(enums, enum{
+					Type:     typ,
+					Names:    name.Name,
+					Receiver: strings.ToLower(typ[:1]),
+				})
+			}
+		}
+	}
+
+	return enums
+}
+
+// isNames reports whether the i-th value of spec is an enum.Names literal
+func isNames(spec *ast.ValueSpec, i int) bool {
+	if i >= len(spec.Values) {
+		return false
+	}
+
+	lit, ok := spec.Values[i].(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+
+	sel, ok := lit.Type.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "enum" && sel.Sel.Name == "Names"
+}
@@ -0,0 +1,96 @@
+package yaml
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mantle/pkg/util/objutil"
+)
+
+// Error is an error located in a YAML file
+type Error struct {
+	File string
+	Position
+	Err error
+}
+
+func (e *Error) Error() string {
+	location := e.Position.String()
+	if len(e.File) > 0 {
+		location = e.File + ":" + location
+	}
+
+	return location + ": " + e.Err.Error()
+}
+
+var syntaxErrorLineRegexp = regexp.MustCompile(`yaml: line (\d+):`)
+
+// Locate finds where err occurred in a YAML document: either the line of
+// a YAML syntax error, or the position of the path in err's context (see
+// objutil.ErrorAtPath), which is then dropped from the message.
+// It returns false if err can't be located.
+func Locate(err error, positions Positions) (*Error, bool) {
+	if located, ok := err.(*Error); ok {
+		return located, true
+	}
+
+	if path, ok := objutil.ErrorPath(err); ok {
+		if pos, ok := positions.Lookup(path); ok {
+			return &Error{Position: pos, Err: objutil.WithoutErrorPath(err)}, true
+		}
+	}
+
+	if matches := syntaxErrorLineRegexp.FindStringSubmatch(err.Error()); matches != nil {
+		line, _ := strconv.Atoi(matches[1])
+		return &Error{Position: Position{Line: line}, Err: err}, true
+	}
+
+	return nil, false
+}
+
+// LocateInFile is Locate for a document that starts at the given line
+// of a file. Errors that can't be located are returned unchanged.
+func LocateInFile(err error, file string, doc Document, positions Positions) error {
+	located, ok := Locate(err, positions)
+	if !ok {
+		return err
+	}
+
+	return &Error{
+		File:     file,
+		Position: Position{Line: located.Line + doc.Line - 1, Column: located.Column},
+		Err:      located.Err,
+	}
+}
+
+// Document is a single document in a multi-document YAML file
+type Document struct {
+	Data []byte
+	// Line is the line of the file that the document starts at
+	Line int
+}
+
+// SplitDocuments splits a multi-document YAML file at its "---" separators
+func SplitDocuments(y []byte) []Document {
+	var docs []Document
+
+	doc := Document{Line: 1}
+	var data [][]byte
+	for n, line := range bytes.Split(y, []byte("\n")) {
+		text := strings.TrimRight(string(line), " \t\r")
+		if text == "---" || strings.HasPrefix(text, "--- ") {
+			doc.Data = bytes.Join(data, []byte("\n"))
+			docs = append(docs, doc)
+
+			doc = Document{Line: n + 2}
+			data = nil
+			continue
+		}
+		data = append(data, line)
+	}
+
+	doc.Data = bytes.Join(data, []byte("\n"))
+	return append(docs, doc)
+}
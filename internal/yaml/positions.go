@@ -0,0 +1,160 @@
+package yaml
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Position is a line and column in a YAML document, both starting at 1.
+// A zero Column means only the line is known.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	if p.Column == 0 {
+		return strconv.Itoa(p.Line)
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Positions maps the paths in a YAML document to the positions they're
+// written at. Paths are dot-separated keys and sequence indices,
+// e.g. spec.containers.0.image
+type Positions map[string]Position
+
+// Lookup returns the position of path. If the path isn't in the
+// document (e.g. a defaulted field), it returns the position of the
+// closest enclosing path that is.
+func (p Positions) Lookup(path string) (Position, bool) {
+	path = normalizePath(path)
+	for {
+		if pos, ok := p[path]; ok {
+			return pos, true
+		}
+
+		i := strings.LastIndex(path, ".")
+		if i < 0 {
+			return Position{}, false
+		}
+		path = path[:i]
+	}
+}
+
+var indexRegexp = regexp.MustCompile(`\[([^\]]*)\]`)
+
+// normalizePath converts a field path (e.g. containers[0].image)
+// or a json decoder path (e.g. $.containers.0.image) into the
+// dot-separated form used by Positions.
+func normalizePath(path string) string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	path = indexRegexp.ReplaceAllString(path, ".$1")
+	return strings.TrimPrefix(path, ".")
+}
+
+// ParsePositions returns the positions of the paths of a YAML document,
+// as recorded by the parser. See Unmarshal for locating decoding errors.
+func ParsePositions(y []byte) (Positions, error) {
+	_, positions, err := yamlToJSON(y, nil)
+	return positions, err
+}
+
+// parsePositions records the position of every key and sequence item of
+// a parsed YAML document. Values reached through an alias, including the
+// keys of a merged (<<) mapping, are positioned at the alias.
+func parsePositions(y []byte) (Positions, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(y, &doc); err != nil {
+		return nil, err
+	}
+
+	positions := Positions{}
+	addPositions(positions, &doc, nil, nil)
+	return positions, nil
+}
+
+// addPositions records the positions of node's children, whose parent is
+// at path. at overrides the position of the children when they're reached
+// through an alias.
+func addPositions(positions Positions, node *yamlv3.Node, path []string, at *yamlv3.Node) {
+	switch node.Kind {
+	case yamlv3.DocumentNode:
+		for _, child := range node.Content {
+			addPositions(positions, child, path, at)
+		}
+	case yamlv3.AliasNode:
+		if at == nil {
+			at = node
+		}
+		addPositions(positions, node.Alias, path, at)
+	case yamlv3.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := appendPath(path, strconv.Itoa(i))
+			positions.add(itemPath, item, at)
+			addPositions(positions, item, itemPath, at)
+		}
+	case yamlv3.MappingNode:
+		keys := map[string]bool{}
+		var merges []*yamlv3.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Kind != yamlv3.ScalarNode {
+				continue
+			}
+			if key.Tag == "!!merge" {
+				merges = append(merges, value)
+				continue
+			}
+
+			keys[key.Value] = true
+			keyPath := appendPath(path, key.Value)
+			positions.add(keyPath, key, at)
+			addPositions(positions, value, keyPath, at)
+		}
+
+		// Merging is shallow: a key written in the mapping replaces the
+		// merged key and everything under it, and the first of several
+		// merged mappings wins.
+		prefix := strings.Join(appendPath(path, ""), ".")
+		for _, merge := range merges {
+			sources := []*yamlv3.Node{merge}
+			if merge.Kind == yamlv3.SequenceNode {
+				sources = merge.Content
+			}
+			for _, source := range sources {
+				merged := Positions{}
+				addPositions(merged, source, path, at)
+
+				mergedKeys := map[string]bool{}
+				for p, pos := range merged {
+					key := strings.SplitN(strings.TrimPrefix(p, prefix), ".", 2)[0]
+					if !keys[key] {
+						positions[p] = pos
+						mergedKeys[key] = true
+					}
+				}
+				for key := range mergedKeys {
+					keys[key] = true
+				}
+			}
+		}
+	}
+}
+
+func (p Positions) add(path []string, node, at *yamlv3.Node) {
+	if at != nil {
+		node = at
+	}
+	p[strings.Join(path, ".")] = Position{Line: node.Line, Column: node.Column}
+}
+
+func appendPath(path []string, segment string) []string {
+	p := make([]string, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, segment)
+}
@@ -0,0 +1,137 @@
+package yaml
+
+import (
+	"reflect"
+	"testing"
+
+	"mantle/pkg/util/objutil"
+
+	serrors "github.com/koki/structurederrors"
+)
+
+const positionsDoc = `pod:
+  name: web
+  labels: &labels
+    app: web
+  containers:
+  - name: nginx
+    image: nginx
+    env:
+    - FOO=bar
+  - name: sidecar
+    args: |
+      not: a key
+    command: ["sh", "-c",
+      "run"]
+    ports: [{name: http, containerPort: 80}]
+  - <<: &base
+      image: busybox
+      pull: always
+    name: debug
+    pull: never
+  selector: *labels
+  volumes: {}
+`
+
+func TestParsePositions(t *testing.T) {
+	positions, err := ParsePositions([]byte(positionsDoc))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := map[string]Position{
+		"pod":                           {Line: 1, Column: 1},
+		"pod.name":                      {Line: 2, Column: 3},
+		"pod.containers.0":              {Line: 6, Column: 5},
+		"pod.containers.0.image":        {Line: 7, Column: 5},
+		"pod.containers.0.env.0":        {Line: 9, Column: 7},
+		"pod.containers.1.args":         {Line: 11, Column: 5},
+		"pod.containers.1.command.2":    {Line: 14, Column: 7},
+		"pod.containers.1.ports.0.name": {Line: 15, Column: 14},
+		"pod.containers.2.image":        {Line: 17, Column: 7},
+		"pod.containers.2.pull":         {Line: 20, Column: 5},
+		"pod.selector.app":              {Line: 21, Column: 13},
+		"pod.volumes":                   {Line: 22, Column: 3},
+		"$.pod.containers.1.name":       {Line: 10, Column: 5},
+		"pod.containers[0].env":         {Line: 8, Column: 5},
+		"pod.containers.0.ports":        {Line: 6, Column: 5},
+	}
+
+	for path, expected := range tests {
+		pos, ok := positions.Lookup(path)
+		if !ok {
+			t.Errorf("%s: not found", path)
+			continue
+		}
+		if !reflect.DeepEqual(pos, expected) {
+			t.Errorf("%s: expected %s, got %s", path, expected, pos)
+		}
+	}
+
+	if _, ok := positions["pod.containers.1.args.not"]; ok {
+		t.Errorf("block scalar content shouldn't be positioned")
+	}
+	if _, ok := positions["pod.containers.2.<<"]; ok {
+		t.Errorf("merge keys shouldn't be positioned")
+	}
+}
+
+func TestUnmarshalLocatesErrors(t *testing.T) {
+	type container struct {
+		Name string `json:"name"`
+		Env  []int  `json:"env"`
+	}
+	type pod struct {
+		Containers []container `json:"containers"`
+	}
+
+	doc := "containers:\n- name: a\n  env:\n  - x\n"
+	err := Unmarshal([]byte(doc), &pod{})
+	located, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected a located error, got %T: %v", err, err)
+	}
+	if located.Line != 3 && located.Line != 4 {
+		t.Errorf("expected the error on the env lines, got %s", located.Position)
+	}
+}
+
+func TestLocateDropsPaths(t *testing.T) {
+	positions, err := ParsePositions([]byte(positionsDoc))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = objutil.ErrorAtPath(serrors.ContextualizeErrorf(errString("bad"), "image"), "containers", 0)
+	err = objutil.ErrorAtPath(err, "pod")
+	located, ok := Locate(err, positions)
+	if !ok {
+		t.Fatalf("expected %v to be located", err)
+	}
+	if located.Error() != "6:5: image: bad" {
+		t.Errorf("unexpected error %q", located)
+	}
+}
+
+func TestSplitDocuments(t *testing.T) {
+	docs := SplitDocuments([]byte("a: 1\n---\nb: 2\nc: 3\n--- \nd: 4"))
+	if len(docs) != 3 {
+		t.Fatalf("expected 3 documents, got %d", len(docs))
+	}
+
+	lines := []int{docs[0].Line, docs[1].Line, docs[2].Line}
+	if !reflect.DeepEqual(lines, []int{1, 3, 6}) {
+		t.Errorf("unexpected document lines %v", lines)
+	}
+
+	err := LocateInFile(&Error{Position: Position{Line: 2, Column: 1}, Err: errString("bad")}, "f.yaml", docs[1], nil)
+	if err.Error() != "f.yaml:4:1: bad" {
+		t.Errorf("unexpected error %q", err)
+	}
+}
+
+type errString string
+
+func (e errString) Error() string {
+	return string(e)
+}
@@ -28,19 +28,35 @@ func Marshal(o interface{}) ([]byte, error) {
 }
 
 // Converts YAML to JSON then uses JSON to unmarshal into an object.
+// Errors are returned as *Error, with their line and column, where
+// they can be located in the YAML.
 func Unmarshal(y []byte, o interface{}) error {
+	_, err := UnmarshalPositions(y, o)
+	return err
+}
+
+// UnmarshalPositions is Unmarshal, also returning the positions of the
+// paths of the document, e.g. to locate errors found after decoding.
+func UnmarshalPositions(y []byte, o interface{}) (Positions, error) {
 	vo := reflect.ValueOf(o)
-	j, err := yamlToJSON(y, &vo)
+	j, positions, err := yamlToJSON(y, &vo)
 	if err != nil {
-		return fmt.Errorf("error converting YAML to JSON: %v", err)
+		err = fmt.Errorf("error converting YAML to JSON: %v", err)
+		if located, ok := Locate(err, nil); ok {
+			return nil, located
+		}
+		return nil, err
 	}
 
 	err = json.Unmarshal(j, o)
 	if err != nil {
-		return fmt.Errorf("error unmarshaling JSON: %v", err)
+		if located, ok := Locate(err, positions); ok {
+			return nil, located
+		}
+		return nil, fmt.Errorf("error unmarshaling JSON: %v", err)
 	}
 
-	return nil
+	return positions, nil
 }
 
 // Convert JSON to YAML.
@@ -65,22 +81,31 @@ func JSONToYAML(j []byte) ([]byte, error) {
 // this method should be a no-op.
 //
 // Things YAML can do that are not supported by JSON:
-// * In YAML you can have binary and null keys in your maps. These are invalid
-//   in JSON. (int and float keys are converted to strings.)
-// * Binary data in YAML with the !!binary tag is not supported. If you want to
-//   use binary data with this library, encode the data as base64 as usual but do
-//   not use the !!binary tag in your YAML. This will ensure the original base64
-//   encoded data makes it all the way through to the JSON.
+//   - In YAML you can have binary and null keys in your maps. These are invalid
+//     in JSON. (int and float keys are converted to strings.)
+//   - Binary data in YAML with the !!binary tag is not supported. If you want to
+//     use binary data with this library, encode the data as base64 as usual but do
+//     not use the !!binary tag in your YAML. This will ensure the original base64
+//     encoded data makes it all the way through to the JSON.
 func YAMLToJSON(y []byte) ([]byte, error) {
-	return yamlToJSON(y, nil)
+	j, _, err := yamlToJSON(y, nil)
+	return j, err
 }
 
-func yamlToJSON(y []byte, jsonTarget *reflect.Value) ([]byte, error) {
+// yamlToJSON also returns the positions of the paths of the document,
+// to locate errors in the converted object.
+func yamlToJSON(y []byte, jsonTarget *reflect.Value) ([]byte, Positions, error) {
 	// Convert the YAML to an object.
 	var yamlObj interface{}
 	err := yaml.Unmarshal(y, &yamlObj)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Record where each path is written, to locate errors in the object.
+	positions, err := parsePositions(y)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// YAML objects are not completely compatible with JSON objects (e.g. you
@@ -89,11 +114,12 @@ func yamlToJSON(y []byte, jsonTarget *reflect.Value) ([]byte, error) {
 	// incompatibilties happen along the way.
 	jsonObj, err := convertToJSONableObject(yamlObj, jsonTarget)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Convert this object to JSON and return the data.
-	return json.Marshal(jsonObj)
+	j, err := json.Marshal(jsonObj)
+	return j, positions, err
 }
 
 func convertToJSONableObject(yamlObj interface{}, jsonTarget *reflect.Value) (interface{}, error) {
@@ -273,6 +299,4 @@ func convertToJSONableObject(yamlObj interface{}, jsonTarget *reflect.Value) (in
 		}
 		return yamlObj, nil
 	}
-
-	return nil, nil
 }
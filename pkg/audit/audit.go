@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/core/pod/podtemplate"
+	"mantle/pkg/report"
+
+	serrors "github.com/koki/structurederrors"
+)
+
+// Level is a pod security standard. Each level includes
+// the checks of the levels below it.
+type Level string
+
+const (
+	LevelPrivileged Level = "privileged"
+	LevelBaseline   Level = "baseline"
+	LevelRestricted Level = "restricted"
+)
+
+// ParseLevel parses the name of a pod security standard
+func ParseLevel(s string) (Level, error) {
+	switch level := Level(strings.ToLower(s)); level {
+	case LevelPrivileged, LevelBaseline, LevelRestricted:
+		return level, nil
+	default:
+		return "", serrors.InvalidValueErrorf(s, "expected %s, %s or %s", LevelPrivileged, LevelBaseline, LevelRestricted)
+	}
+}
+
+// Bundle checks the pod template of every pod and workload in
+// the bundle against the given level.
+func Bundle(b *bundle.Bundle, level Level) ([]report.Finding, error) {
+	templates, err := b.PodTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []report.Finding
+	for _, template := range templates {
+		podFindings, err := Pod(template.Pod, level)
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "%s %s", template.Object.Location(), template.Object.ID())
+		}
+
+		for _, finding := range podFindings {
+			finding.Object = template.Object.ID()
+			finding.Location = template.Object.Location()
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, nil
+}
+
+// Pod checks a pod against the given level. Security profiles are
+// expanded first, so findings point at the fields they expand into.
+func Pod(p *pod.Pod, level Level) ([]report.Finding, error) {
+	pt, err := p.PodTemplate.ExpandSecurityProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	a := &auditor{pt: pt}
+
+	switch level {
+	case LevelPrivileged:
+	case LevelBaseline:
+		a.baseline()
+	case LevelRestricted:
+		a.baseline()
+		a.restricted()
+	default:
+		return nil, serrors.InvalidValueErrorf(level, "unknown pod security level")
+	}
+
+	return a.findings, nil
+}
+
+type auditor struct {
+	pt       *podtemplate.PodTemplate
+	findings []report.Finding
+}
+
+func (a *auditor) fail(rule, path, msgFormat string, args ...interface{}) {
+	a.findings = append(a.findings, report.Finding{
+		Rule:     rule,
+		Severity: report.SeverityError,
+		Path:     path,
+		Message:  fmt.Sprintf(msgFormat, args...),
+	})
+}
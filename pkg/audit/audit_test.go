@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"mantle/pkg/bundle"
+)
+
+const testBundle = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: broker
+spec:
+  template:
+    spec:
+      hostNetwork: true
+      containers:
+      - name: broker
+        image: apachepulsar/pulsar
+        ports:
+        - containerPort: 8080
+          hostPort: 8080
+        securityContext:
+          privileged: true
+          capabilities:
+            add: [SYS_ADMIN]
+---
+pod:
+  name: hardened
+  security_profile: restricted
+  containers:
+  - name: bookie
+    image: apachepulsar/pulsar
+`
+
+func auditTestBundle(t *testing.T, level Level) []string {
+	objects, err := bundle.Read("bundle.yaml", strings.NewReader(testBundle))
+	if err != nil {
+		t.Fatalf("unexpected error reading bundle: %v", err)
+	}
+
+	findings, err := Bundle(&bundle.Bundle{Objects: objects}, level)
+	if err != nil {
+		t.Fatalf("unexpected error auditing bundle: %v", err)
+	}
+
+	var results []string
+	for _, finding := range findings {
+		results = append(results, finding.Object+" "+finding.Path+" "+finding.Rule)
+	}
+	return results
+}
+
+func TestAuditBaseline(t *testing.T) {
+	expected := []string{
+		"Deployment/broker host_mode host-namespaces",
+		"Deployment/broker containers[0].privileged privileged",
+		"Deployment/broker containers[0].cap_add capabilities",
+		"Deployment/broker containers[0].expose[0] host-ports",
+	}
+
+	if results := auditTestBundle(t, LevelBaseline); !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected findings %v, got %v", expected, results)
+	}
+}
+
+func TestAuditRestricted(t *testing.T) {
+	results := auditTestBundle(t, LevelRestricted)
+
+	for _, result := range results {
+		if strings.HasPrefix(result, "Pod/hardened") {
+			t.Errorf("restricted security profile failed the restricted level: %s", result)
+		}
+	}
+
+	for _, expected := range []string{
+		"Deployment/broker containers[0].allow_escalation privilege-escalation",
+		"Deployment/broker containers[0].force_non_root run-as-non-root",
+		"Deployment/broker containers[0].seccomp seccomp",
+		"Deployment/broker containers[0].cap_drop capabilities",
+	} {
+		found := false
+		for _, result := range results {
+			found = found || result == expected
+		}
+		if !found {
+			t.Errorf("expected finding %q in %v", expected, results)
+		}
+	}
+}
+
+func TestAuditPrivileged(t *testing.T) {
+	if results := auditTestBundle(t, LevelPrivileged); len(results) > 0 {
+		t.Errorf("expected no findings at the privileged level, got %v", results)
+	}
+}
@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/pkg/core/pod/container"
+	"mantle/pkg/core/pod/podtemplate"
+	"mantle/pkg/core/selinux"
+)
+
+// baselineCapabilities are the capabilities a baseline container may add
+var baselineCapabilities = map[string]bool{
+	"AUDIT_WRITE":      true,
+	"CHOWN":            true,
+	"DAC_OVERRIDE":     true,
+	"FOWNER":           true,
+	"FSETID":           true,
+	"KILL":             true,
+	"MKNOD":            true,
+	"NET_BIND_SERVICE": true,
+	"SETFCAP":          true,
+	"SETGID":           true,
+	"SETPCAP":          true,
+	"SETUID":           true,
+	"SYS_CHROOT":       true,
+}
+
+// baselineSELinuxTypes are the SELinux types a baseline pod may use
+var baselineSELinuxTypes = map[string]bool{
+	"":                 true,
+	"container_t":      true,
+	"container_init_t": true,
+	"container_kvm_t":  true,
+}
+
+// safeSysctls are the namespaced sysctls that can't affect other pods
+var safeSysctls = map[string]bool{
+	"kernel.shm_rmid_forced":              true,
+	"net.ipv4.ip_local_port_range":        true,
+	"net.ipv4.ip_unprivileged_port_start": true,
+	"net.ipv4.tcp_syncookies":             true,
+	"net.ipv4.ping_group_range":           true,
+}
+
+func (a *auditor) baseline() {
+	for _, mode := range a.pt.HostMode {
+		a.fail("host-namespaces", "host_mode", "pod shares the host's %s namespace", hostModeName(mode))
+	}
+
+	for _, name := range a.pt.VolumeNames() {
+		if a.pt.Volumes[name].HostPath != nil {
+			a.fail("host-path", fmt.Sprintf("volumes.%s", name), "hostPath volumes are forbidden")
+		}
+	}
+
+	a.checkSELinux(a.pt.SELinux, "selinux")
+
+	if a.pt.Seccomp == container.ProfileUnconfined {
+		a.fail("seccomp", "seccomp", "seccomp profile must not be %s", container.ProfileUnconfined)
+	}
+
+	for i, sysctl := range a.pt.Sysctls {
+		if !safeSysctls[sysctl.Name] {
+			a.fail("sysctls", fmt.Sprintf("sysctls[%d]", i), "sysctl %s is not in the safe set", sysctl.Name)
+		}
+	}
+
+	a.pt.EachContainer(func(c *container.Container, path string) {
+		if c.Privileged != nil && *c.Privileged {
+			a.fail("privileged", path+".privileged", "privileged containers are forbidden")
+		}
+
+		for _, capability := range c.AddCapabilities {
+			if !baselineCapabilities[normalizeCapability(capability)] {
+				a.fail("capabilities", path+".cap_add", "adding capability %s is forbidden", capability)
+			}
+		}
+
+		for j, port := range c.Expose {
+			if len(port.HostPort) > 0 && port.HostPort != "0" {
+				a.fail("host-ports", fmt.Sprintf("%s.expose[%d]", path, j), "host port %s is forbidden", port.HostPort)
+			}
+		}
+
+		a.checkSELinux(c.SELinux, path+".selinux")
+
+		if c.Seccomp == container.ProfileUnconfined {
+			a.fail("seccomp", path+".seccomp", "seccomp profile must not be %s", container.ProfileUnconfined)
+		}
+
+		appArmor := c.AppArmor
+		if len(appArmor) == 0 {
+			appArmor = a.pt.AppArmor
+		}
+		if len(appArmor) > 0 && appArmor != container.ProfileRuntimeDefault &&
+			!strings.HasPrefix(appArmor, container.ProfileLocalhostPrefix) {
+			a.fail("apparmor", path+".apparmor", "AppArmor profile must be %s or %s<profile>",
+				container.ProfileRuntimeDefault, container.ProfileLocalhostPrefix)
+		}
+	})
+}
+
+func (a *auditor) checkSELinux(sel *selinux.SELinux, path string) {
+	if sel == nil {
+		return
+	}
+
+	if !baselineSELinuxTypes[sel.Type] {
+		a.fail("selinux", path, "SELinux type %s is forbidden", sel.Type)
+	}
+
+	if len(sel.User) > 0 || len(sel.Role) > 0 {
+		a.fail("selinux", path, "custom SELinux users and roles are forbidden")
+	}
+}
+
+func hostModeName(mode podtemplate.HostMode) string {
+	switch mode {
+	case podtemplate.HostModeNet:
+		return "network"
+	case podtemplate.HostModePID:
+		return "PID"
+	case podtemplate.HostModeIPC:
+		return "IPC"
+	default:
+		return "unknown"
+	}
+}
+
+// normalizeCapability strips the optional CAP_ prefix of a capability
+func normalizeCapability(capability string) string {
+	return strings.TrimPrefix(strings.ToUpper(capability), "CAP_")
+}
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/pkg/core/pod/container"
+	"mantle/pkg/core/pod/volume"
+)
+
+// restrictedVolume reports whether a restricted pod may use the volume
+func restrictedVolume(v volume.Volume) bool {
+	return v.ConfigMap != nil || v.DownwardAPI != nil || v.EmptyDir != nil ||
+		v.PVC != nil || v.Projected != nil || v.Secret != nil
+}
+
+func (a *auditor) restricted() {
+	for _, name := range a.pt.VolumeNames() {
+		// hostPath volumes are already reported by the baseline checks.
+		if v := a.pt.Volumes[name]; v.HostPath == nil && !restrictedVolume(v) {
+			a.fail("volume-types", fmt.Sprintf("volumes.%s", name),
+				"only configmap, downward API, empty dir, pvc, projected and secret volumes are allowed")
+		}
+	}
+
+	if a.pt.UID != nil && *a.pt.UID == 0 {
+		a.fail("run-as-user", "uid", "pod must not run as root")
+	}
+
+	a.pt.EachContainer(func(c *container.Container, path string) {
+		if c.AllowEscalation == nil || *c.AllowEscalation {
+			a.fail("privilege-escalation", path+".allow_escalation", "allow_escalation must be false")
+		}
+
+		forceNonRoot := c.ForceNonRoot
+		if forceNonRoot == nil {
+			forceNonRoot = a.pt.ForceNonRoot
+		}
+		if forceNonRoot == nil || !*forceNonRoot {
+			a.fail("run-as-non-root", path+".force_non_root", "force_non_root must be true on the container or pod")
+		}
+
+		if c.UID != nil && *c.UID == 0 {
+			a.fail("run-as-user", path+".uid", "container must not run as root")
+		}
+
+		seccomp := c.Seccomp
+		if len(seccomp) == 0 {
+			seccomp = a.pt.Seccomp
+		}
+		if seccomp != container.ProfileRuntimeDefault && seccomp != container.SeccompDockerDefault &&
+			!strings.HasPrefix(seccomp, container.ProfileLocalhostPrefix) {
+			a.fail("seccomp", path+".seccomp", "seccomp profile must be %s or %s<profile> on the container or pod",
+				container.ProfileRuntimeDefault, container.ProfileLocalhostPrefix)
+		}
+
+		dropsAll := false
+		for _, capability := range c.DelCapabilities {
+			if normalizeCapability(capability) == "ALL" {
+				dropsAll = true
+			}
+		}
+		if !dropsAll {
+			a.fail("capabilities", path+".cap_drop", "containers must drop ALL capabilities")
+		}
+
+		for _, capability := range c.AddCapabilities {
+			// Capabilities outside the baseline set are already reported.
+			capability = normalizeCapability(capability)
+			if baselineCapabilities[capability] && capability != "NET_BIND_SERVICE" {
+				a.fail("capabilities", path+".cap_add", "only NET_BIND_SERVICE may be added")
+			}
+		}
+	})
+}
@@ -0,0 +1,197 @@
+package bundle
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"mantle/internal/yaml"
+	"mantle/pkg/codec"
+	"mantle/pkg/core/configmap"
+	"mantle/pkg/core/pod"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Stdin is the source name for a bundle read from standard input.
+const Stdin = "-"
+
+// Bundle is a set of objects loaded from one or more
+// multi-document YAML files
+type Bundle struct {
+	Objects []*Object
+}
+
+// Object is a single document in a bundle. It holds either a kubernetes
+// object (Kube) or a mantle object (Mantle), never both.
+type Object struct {
+	Source string
+	Index  int
+
+	Kind      string
+	Name      string
+	Namespace string
+
+	Kube   runtime.Object
+	Mantle interface{}
+
+	doc       yaml.Document
+	positions yaml.Positions
+}
+
+// ID identifies the object in reports, e.g. Deployment/pulsar/broker
+func (o *Object) ID() string {
+	if len(o.Namespace) > 0 {
+		return fmt.Sprintf("%s/%s/%s", o.Kind, o.Namespace, o.Name)
+	}
+	return fmt.Sprintf("%s/%s", o.Kind, o.Name)
+}
+
+// Location identifies the document the object was read from, e.g. broker.yaml#2
+func (o *Object) Location() string {
+	return fmt.Sprintf("%s#%d", o.Source, o.Index)
+}
+
+// Load reads every document of the given files into a bundle.
+// A path of "-" reads from standard input.
+func Load(paths []string) (*Bundle, error) {
+	b := &Bundle{}
+
+	for _, path := range paths {
+		objects, err := loadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		b.Objects = append(b.Objects, objects...)
+	}
+
+	return b, nil
+}
+
+func loadFile(path string) ([]*Object, error) {
+	if path == Stdin {
+		return Read(path, os.Stdin)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(path, f)
+}
+
+// Read parses every document in r, which may hold kubernetes objects
+// and mantle documents separated by "---". Errors are located in the
+// source where possible (see yaml.Error).
+func Read(source string, r io.Reader) ([]*Object, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []*Object
+	for index, doc := range yaml.SplitDocuments(data) {
+		if len(bytes.TrimSpace(doc.Data)) == 0 {
+			continue
+		}
+
+		object, positions, err := readObject(doc.Data)
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(yaml.LocateInFile(err, source, doc, positions), "%s#%d", source, index)
+		}
+		if object == nil {
+			continue
+		}
+
+		object.Source = source
+		object.Index = index
+		object.doc = doc
+		object.positions = positions
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// Locate returns err with the position in the source file of the path
+// in its context, if it has one. See objutil.ErrorAtPath.
+func (o *Object) Locate(err error) error {
+	return yaml.LocateInFile(err, o.Source, o.doc, o.positions)
+}
+
+// Position returns where path (e.g. pod.containers.0.image) is written
+// in the source file. If the path isn't written, e.g. it was defaulted,
+// it returns the position of the closest enclosing path that is, and
+// false. The position is zero if not even the document's root is found.
+func (o *Object) Position(path string) (yaml.Position, bool) {
+	pos, ok := o.positions.Lookup(path)
+	if !ok {
+		return yaml.Position{}, false
+	}
+
+	_, exact := o.positions[path]
+	return yaml.Position{Line: pos.Line + o.doc.Line - 1, Column: pos.Column}, exact
+}
+
+// readObject also returns the positions of the document's paths, to
+// locate errors found in the object later
+func readObject(doc []byte) (*Object, yaml.Positions, error) {
+	obj := map[string]interface{}{}
+	positions, err := yaml.UnmarshalPositions(doc, &obj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Documents holding only comments unmarshal to an empty object.
+	if len(obj) == 0 {
+		return nil, nil, nil
+	}
+
+	if codec.IsMantleType(obj) {
+		mantleObj, err := codec.ParseMantleType(obj)
+		if err != nil {
+			return nil, positions, err
+		}
+		return newMantleObject(mantleObj), positions, nil
+	}
+
+	kubeObj, err := codec.ParseKubeNativeType(obj)
+	if err != nil {
+		return nil, positions, err
+	}
+
+	object := &Object{
+		Kind: kubeObj.GetObjectKind().GroupVersionKind().Kind,
+		Kube: kubeObj,
+	}
+	if accessor, err := meta.Accessor(kubeObj); err == nil {
+		object.Name = accessor.GetName()
+		object.Namespace = accessor.GetNamespace()
+	}
+
+	return object, positions, nil
+}
+
+func newMantleObject(mantleObj interface{}) *Object {
+	object := &Object{Mantle: mantleObj}
+
+	switch obj := mantleObj.(type) {
+	case *pod.Pod:
+		object.Kind = "Pod"
+		object.Name = obj.Name
+		object.Namespace = obj.Namespace
+	case *configmap.ConfigMap:
+		object.Kind = "ConfigMap"
+		object.Name = obj.Name
+		object.Namespace = obj.Namespace
+	}
+
+	return object
+}
@@ -0,0 +1,132 @@
+package bundle
+
+import (
+	"mantle/pkg/core/pod"
+	"mantle/pkg/util/objutil"
+
+	serrors "github.com/koki/structurederrors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	batchv2alpha1 "k8s.io/api/batch/v2alpha1"
+	"k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodTemplate is a pod template found in a bundle object, converted to
+// a mantle pod. For workloads, the pod holds the workload's template.
+type PodTemplate struct {
+	Object *Object
+	Pod    *pod.Pod
+}
+
+// PodTemplates returns the pod template of every pod
+// and workload in the bundle
+func (b *Bundle) PodTemplates() ([]PodTemplate, error) {
+	var templates []PodTemplate
+
+	for _, object := range b.Objects {
+		p, err := object.PodTemplate()
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(object.Locate(err), "%s %s", object.Location(), object.ID())
+		}
+		if p != nil {
+			templates = append(templates, PodTemplate{Object: object, Pod: p})
+		}
+	}
+
+	return templates, nil
+}
+
+// PodTemplate returns the object's pod, or the pod template of a
+// workload, as a mantle pod. A template without a namespace gets the
+// workload's. It returns nil for other objects.
+func (o *Object) PodTemplate() (*pod.Pod, error) {
+	if p, ok := o.Mantle.(*pod.Pod); ok {
+		return p, nil
+	}
+
+	if kubePod, ok := o.Kube.(*v1.Pod); ok {
+		return pod.NewPodFromKubePod(kubePod)
+	}
+
+	template, path := kubePodTemplateSpec(o.Kube)
+	if template == nil {
+		return nil, nil
+	}
+
+	// Templates rarely set a namespace; their pods run in the workload's.
+	meta := template.ObjectMeta
+	if len(meta.Namespace) == 0 {
+		meta.Namespace = o.Namespace
+	}
+
+	p, err := pod.NewPodFromKubePod(&v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: meta,
+		Spec:       template.Spec,
+	})
+	if err != nil {
+		return nil, objutil.ErrorAtPath(err, path...)
+	}
+
+	return p, nil
+}
+
+// KubePodTemplate returns the pod template of a kubernetes workload,
+// along with its path in the workload. It returns nil for pods and
+// other objects.
+func (o *Object) KubePodTemplate() (*v1.PodTemplateSpec, []interface{}) {
+	return kubePodTemplateSpec(o.Kube)
+}
+
+// kubePodTemplateSpec returns the pod template of a workload,
+// along with its path in the workload
+func kubePodTemplateSpec(obj interface{}) (*v1.PodTemplateSpec, []interface{}) {
+	specTemplate := []interface{}{"spec", "template"}
+
+	switch obj := obj.(type) {
+	case *v1.PodTemplate:
+		return &obj.Template, []interface{}{"template"}
+	case *v1.ReplicationController:
+		return obj.Spec.Template, specTemplate
+	case *appsv1.Deployment:
+		return &obj.Spec.Template, specTemplate
+	case *appsv1.StatefulSet:
+		return &obj.Spec.Template, specTemplate
+	case *appsv1.DaemonSet:
+		return &obj.Spec.Template, specTemplate
+	case *appsv1.ReplicaSet:
+		return &obj.Spec.Template, specTemplate
+	case *appsv1beta1.Deployment:
+		return &obj.Spec.Template, specTemplate
+	case *appsv1beta1.StatefulSet:
+		return &obj.Spec.Template, specTemplate
+	case *appsv1beta2.Deployment:
+		return &obj.Spec.Template, specTemplate
+	case *appsv1beta2.StatefulSet:
+		return &obj.Spec.Template, specTemplate
+	case *appsv1beta2.DaemonSet:
+		return &obj.Spec.Template, specTemplate
+	case *appsv1beta2.ReplicaSet:
+		return &obj.Spec.Template, specTemplate
+	case *extensionsv1beta1.Deployment:
+		return &obj.Spec.Template, specTemplate
+	case *extensionsv1beta1.DaemonSet:
+		return &obj.Spec.Template, specTemplate
+	case *extensionsv1beta1.ReplicaSet:
+		return &obj.Spec.Template, specTemplate
+	case *batchv1.Job:
+		return &obj.Spec.Template, specTemplate
+	case *batchv1beta1.CronJob:
+		return &obj.Spec.JobTemplate.Spec.Template, []interface{}{"spec", "jobTemplate", "spec", "template"}
+	case *batchv2alpha1.CronJob:
+		return &obj.Spec.JobTemplate.Spec.Template, []interface{}{"spec", "jobTemplate", "spec", "template"}
+	default:
+		return nil, nil
+	}
+}
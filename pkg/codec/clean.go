@@ -0,0 +1,169 @@
+package codec
+
+import (
+	"strings"
+
+	"mantle/pkg/core/pod"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// LastAppliedAnnotation is where kubectl apply keeps the configuration it last applied
+const LastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+const (
+	defaultServiceAccountName = "default"
+
+	// The tolerations the DefaultTolerationSeconds admission plugin adds
+	defaultTolerationSeconds = 300
+
+	serviceAccountMountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+)
+
+var defaultTolerationKeys = map[string]bool{
+	"node.kubernetes.io/not-ready":   true,
+	"node.kubernetes.io/unreachable": true,
+}
+
+// Clean drops what kubernetes adds to the objects it stores, e.g. as
+// exported by kubectl get -o yaml: the status, the metadata the server
+// sets, the last applied configuration, what admission plugins add, and
+// fields that are equal to the defaults the API server fills in. What's
+// left is a manifest that can be applied again.
+func Clean(kubeObj runtime.Object) {
+	switch obj := kubeObj.(type) {
+	case *v1.ConfigMap:
+		cleanObjectMeta(&obj.ObjectMeta)
+	case *v1.Pod:
+		cleanObjectMeta(&obj.ObjectMeta)
+		cleanPodSpec(&obj.Spec)
+		obj.Status = v1.PodStatus{}
+	}
+
+	StripDefaults(kubeObj)
+}
+
+// StripDefaults drops the fields that are equal to the defaults the API
+// server fills in, the same ones mantle leaves out when converting from
+// kubernetes. See pod.StripDefaults.
+func StripDefaults(kubeObj runtime.Object) {
+	if p, ok := kubeObj.(*v1.Pod); ok {
+		pod.StripDefaults(p)
+	}
+}
+
+func cleanObjectMeta(meta *metav1.ObjectMeta) {
+	meta.UID = ""
+	meta.ResourceVersion = ""
+	meta.SelfLink = ""
+	meta.Generation = 0
+	meta.CreationTimestamp = metav1.Time{}
+	meta.DeletionTimestamp = nil
+	meta.DeletionGracePeriodSeconds = nil
+
+	delete(meta.Annotations, LastAppliedAnnotation)
+	if len(meta.Annotations) == 0 {
+		meta.Annotations = nil
+	}
+}
+
+func cleanPodSpec(spec *v1.PodSpec) {
+	// Set by the scheduler
+	spec.NodeName = ""
+
+	if spec.SecurityContext != nil && isEmptyPodSecurityContext(spec.SecurityContext) {
+		spec.SecurityContext = nil
+	}
+	if spec.Priority != nil && *spec.Priority == 0 && len(spec.PriorityClassName) == 0 {
+		spec.Priority = nil
+	}
+
+	// The server keeps the deprecated alias in sync with the name.
+	if spec.DeprecatedServiceAccount == spec.ServiceAccountName {
+		spec.DeprecatedServiceAccount = ""
+	}
+	tokenVolume := serviceAccountTokenVolume(spec)
+	if spec.ServiceAccountName == defaultServiceAccountName {
+		spec.ServiceAccountName = ""
+	}
+
+	var volumes []v1.Volume
+	for _, volume := range spec.Volumes {
+		if volume.Name != tokenVolume {
+			volumes = append(volumes, volume)
+		}
+	}
+	spec.Volumes = volumes
+
+	var tolerations []v1.Toleration
+	for _, toleration := range spec.Tolerations {
+		if !isDefaultToleration(toleration) {
+			tolerations = append(tolerations, toleration)
+		}
+	}
+	spec.Tolerations = tolerations
+
+	for i := range spec.InitContainers {
+		cleanContainer(&spec.InitContainers[i], tokenVolume)
+	}
+	for i := range spec.Containers {
+		cleanContainer(&spec.Containers[i], tokenVolume)
+	}
+}
+
+// serviceAccountTokenVolume returns the name of the volume the service
+// account admission plugin added for the service account's token, if
+// there is one. It's mounted at the same path in every container.
+func serviceAccountTokenVolume(spec *v1.PodSpec) string {
+	for _, volume := range spec.Volumes {
+		if volume.Secret == nil || !strings.HasPrefix(volume.Name, spec.ServiceAccountName+"-token-") {
+			continue
+		}
+
+		if mountsServiceAccountToken(spec.InitContainers, volume.Name) || mountsServiceAccountToken(spec.Containers, volume.Name) {
+			return volume.Name
+		}
+	}
+
+	return ""
+}
+
+// mountsServiceAccountToken reports whether one of the containers mounts
+// the volume where the service account token goes
+func mountsServiceAccountToken(containers []v1.Container, volumeName string) bool {
+	for _, c := range containers {
+		for _, mount := range c.VolumeMounts {
+			if mount.Name == volumeName && mount.MountPath == serviceAccountMountPath {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func isDefaultToleration(toleration v1.Toleration) bool {
+	return defaultTolerationKeys[toleration.Key] &&
+		toleration.Operator == v1.TolerationOpExists &&
+		toleration.Effect == v1.TaintEffectNoExecute &&
+		toleration.TolerationSeconds != nil && *toleration.TolerationSeconds == defaultTolerationSeconds
+}
+
+func isEmptyPodSecurityContext(sc *v1.PodSecurityContext) bool {
+	return sc.SELinuxOptions == nil && sc.RunAsUser == nil && sc.RunAsGroup == nil &&
+		sc.RunAsNonRoot == nil && len(sc.SupplementalGroups) == 0 && sc.FSGroup == nil &&
+		len(sc.Sysctls) == 0
+}
+
+func cleanContainer(c *v1.Container, tokenVolume string) {
+	var mounts []v1.VolumeMount
+	for _, mount := range c.VolumeMounts {
+		if len(tokenVolume) > 0 && mount.Name == tokenVolume {
+			continue
+		}
+		mounts = append(mounts, mount)
+	}
+	c.VolumeMounts = mounts
+}
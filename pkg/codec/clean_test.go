@@ -0,0 +1,200 @@
+package codec
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mantle/internal/yaml"
+
+	"k8s.io/api/core/v1"
+)
+
+// A pod as kubectl get -o yaml exports it
+const livePod = `
+apiVersion: v1
+kind: Pod
+metadata:
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"apiVersion":"v1","kind":"Pod"}'
+    team: streaming
+  creationTimestamp: "2019-02-01T12:00:00Z"
+  name: broker
+  namespace: pulsar
+  resourceVersion: "4242"
+  selfLink: /api/v1/namespaces/pulsar/pods/broker
+  uid: 5f2c7a1e-2619-11e9
+spec:
+  containers:
+  - image: apachepulsar/pulsar:2.2.1
+    imagePullPolicy: IfNotPresent
+    name: broker
+    readinessProbe:
+      failureThreshold: 3
+      httpGet:
+        path: /status.html
+        port: 8080
+        scheme: HTTP
+      periodSeconds: 10
+      successThreshold: 1
+      timeoutSeconds: 5
+    terminationMessagePath: /dev/termination-log
+    terminationMessagePolicy: File
+    volumeMounts:
+    - mountPath: /pulsar/data
+      name: data
+    - mountPath: /var/run/secrets/kubernetes.io/serviceaccount
+      name: default-token-x7k2p
+      readOnly: true
+  dnsPolicy: ClusterFirst
+  enableServiceLinks: true
+  nodeName: node-1
+  priority: 0
+  restartPolicy: Always
+  schedulerName: default-scheduler
+  securityContext: {}
+  serviceAccount: default
+  serviceAccountName: default
+  terminationGracePeriodSeconds: 30
+  tolerations:
+  - effect: NoSchedule
+    key: dedicated
+    operator: Equal
+    value: pulsar
+  - effect: NoExecute
+    key: node.kubernetes.io/not-ready
+    operator: Exists
+    tolerationSeconds: 300
+  volumes:
+  - name: data
+    emptyDir: {}
+  - name: default-token-x7k2p
+    secret:
+      defaultMode: 420
+      secretName: default-token-x7k2p
+status:
+  phase: Running
+  podIP: 10.1.2.3
+  qosClass: BestEffort
+`
+
+const cleanPod = `
+apiVersion: v1
+kind: Pod
+metadata:
+  annotations:
+    team: streaming
+  creationTimestamp: null
+  name: broker
+  namespace: pulsar
+spec:
+  containers:
+  - image: apachepulsar/pulsar:2.2.1
+    name: broker
+    readinessProbe:
+      httpGet:
+        path: /status.html
+        port: 8080
+      timeoutSeconds: 5
+    resources: {}
+    volumeMounts:
+    - mountPath: /pulsar/data
+      name: data
+  tolerations:
+  - effect: NoSchedule
+    key: dedicated
+    operator: Equal
+    value: pulsar
+  volumes:
+  - name: data
+    emptyDir: {}
+status: {}
+`
+
+func parseTree(t *testing.T, manifest string) interface{} {
+	obj := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(manifest), &obj); err != nil {
+		t.Fatalf("unexpected error parsing manifest: %v", err)
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling manifest: %v", err)
+	}
+	var tree interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		t.Fatalf("unexpected error unmarshalling manifest: %v", err)
+	}
+	return tree
+}
+
+func TestClean(t *testing.T) {
+	obj := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(livePod), &obj); err != nil {
+		t.Fatalf("unexpected error parsing pod: %v", err)
+	}
+	kubeObj, err := ParseKubeNativeType(obj)
+	if err != nil {
+		t.Fatalf("unexpected error parsing pod: %v", err)
+	}
+
+	Clean(kubeObj)
+
+	data, err := json.Marshal(kubeObj)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling pod: %v", err)
+	}
+	var result interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("unexpected error unmarshalling pod: %v", err)
+	}
+
+	if expected := parseTree(t, cleanPod); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestDecodeClean(t *testing.T) {
+	out, err := Decode(strings.NewReader(livePod), DecodeOptions{Clean: true})
+	if err != nil {
+		t.Fatalf("unexpected error decoding pod: %v", err)
+	}
+
+	doc := map[string]map[string]interface{}{}
+	if err := json.NewDecoder(out).Decode(&doc); err != nil {
+		t.Fatalf("unexpected error reading mantle pod: %v", err)
+	}
+
+	// The status is dropped, so the pod has no phase or QOS class.
+	for _, key := range []string{"phase", "qos", "ip"} {
+		if value, ok := doc["pod"][key]; ok {
+			t.Errorf("expected no %s, got %v", key, value)
+		}
+	}
+}
+
+func TestServiceAccountTokenVolumeKeepsContainers(t *testing.T) {
+	mount := v1.VolumeMount{Name: "default-token-x7k2p", MountPath: serviceAccountMountPath}
+	initContainers := make([]v1.Container, 1, 2)
+	initContainers[0] = v1.Container{Name: "init"}
+	spare := initContainers[:2]
+	spare[1] = v1.Container{Name: "spare"}
+
+	spec := &v1.PodSpec{
+		ServiceAccountName: defaultServiceAccountName,
+		Volumes: []v1.Volume{{
+			Name:         mount.Name,
+			VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: mount.Name}},
+		}},
+		InitContainers: initContainers,
+		Containers:     []v1.Container{{Name: "broker", VolumeMounts: []v1.VolumeMount{mount}}},
+	}
+
+	if name := serviceAccountTokenVolume(spec); name != mount.Name {
+		t.Errorf("expected the token volume, got %q", name)
+	}
+	// The spare capacity of the init containers isn't written to.
+	if spare[1].Name != "spare" {
+		t.Errorf("init containers' backing array was overwritten with %q", spare[1].Name)
+	}
+}
@@ -3,47 +3,79 @@ package codec
 import (
 	"bytes"
 	"encoding/json"
-	"errors"
 	"io"
 	"io/ioutil"
+	"reflect"
 
 	"mantle/internal/yaml"
 	"mantle/pkg/core/configmap"
+	"mantle/pkg/core/pod"
+
+	serrors "github.com/koki/structurederrors"
 
 	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
-func Decode(input io.Reader) (io.Reader, error) {
+// DecodeOptions control how kubernetes manifests are decoded
+type DecodeOptions struct {
+	// Clean drops what kubernetes adds to the objects it stores, for
+	// manifests exported from a cluster. See Clean.
+	Clean bool
+}
+
+// Decode converts a kubernetes manifest into a mantle document.
+// Errors are located in the manifest where possible (see yaml.Error).
+func Decode(input io.Reader, opts DecodeOptions) (io.Reader, error) {
 	data, err := ioutil.ReadAll(input)
 	if err != nil {
 		return nil, err
 	}
 	obj := map[string]interface{}{}
-	err = yaml.Unmarshal(data, &obj)
+	positions, err := yaml.UnmarshalPositions(data, &obj)
 	if err != nil {
 		return nil, err
 	}
 
 	kubeObj, err := ParseKubeNativeType(obj)
 	if err != nil {
-		//TBD: parse koki type
 		return nil, err
 	}
 
+	if opts.Clean {
+		Clean(kubeObj)
+	}
+
+	mantleObj, err := FromKube(kubeObj)
+	if err != nil {
+		if located, ok := yaml.Locate(err, positions); ok {
+			return nil, located
+		}
+		return nil, err
+	}
+
+	doc, err := WrapMantleType(mantleObj)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	err = encoder.Encode(doc)
+	return buf, err
+}
+
+// FromKube converts a kubernetes object into the matching mantle object
+func FromKube(kubeObj runtime.Object) (interface{}, error) {
 	switch kubeTypedObj := kubeObj.(type) {
 	case *v1.ConfigMap:
-		cm, err := configmap.NewConfigMapFromKubeConfigMap(kubeTypedObj)
-		if err != nil {
-			return nil, err
-		}
-		buf := &bytes.Buffer{}
-		encoder := json.NewEncoder(buf)
-		err = encoder.Encode(cm)
-		return buf, err
+		return configmap.NewConfigMapFromKubeConfigMap(kubeTypedObj)
+	case *v1.Pod:
+		return pod.NewPodFromKubePod(kubeTypedObj)
+	default:
+		return nil, serrors.InvalidInstanceErrorf(kubeObj, "unsupported kind %s", reflect.TypeOf(kubeObj))
 	}
-	return nil, errors.New("unreachable")
 }
 
 func ParseKubeNativeType(obj map[string]interface{}) (runtime.Object, error) {
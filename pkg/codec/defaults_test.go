@@ -0,0 +1,113 @@
+package codec
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mantle/internal/yaml"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/core/pod/container"
+	"mantle/pkg/core/pod/podtemplate"
+)
+
+// A pod with every default kubernetes fills in written out
+const defaultedPod = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: broker
+spec:
+  containers:
+  - image: apachepulsar/pulsar:2.2.1
+    imagePullPolicy: IfNotPresent
+    name: broker
+    livenessProbe:
+      failureThreshold: 3
+      httpGet:
+        path: /status.html
+        port: 8080
+        scheme: HTTP
+      periodSeconds: 10
+      successThreshold: 1
+      timeoutSeconds: 1
+    ports:
+    - containerPort: 8080
+      name: http
+      protocol: TCP
+    resources: {}
+    terminationMessagePath: /dev/termination-log
+    terminationMessagePolicy: File
+    volumeMounts:
+    - mountPath: /conf
+      name: conf
+  dnsPolicy: ClusterFirst
+  enableServiceLinks: true
+  restartPolicy: Always
+  schedulerName: default-scheduler
+  terminationGracePeriodSeconds: 30
+  volumes:
+  - name: conf
+    configMap:
+      name: broker
+      defaultMode: 420
+`
+
+func TestDefaults(t *testing.T) {
+	obj := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(defaultedPod), &obj); err != nil {
+		t.Fatalf("unexpected error parsing pod: %v", err)
+	}
+	kubeObj, err := ParseKubeNativeType(obj)
+	if err != nil {
+		t.Fatalf("unexpected error parsing pod: %v", err)
+	}
+
+	mantleObj, err := FromKube(kubeObj)
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+
+	// The defaults are left out...
+	mantlePod := mantleObj.(*pod.Pod)
+	if pt := mantlePod.PodTemplate; pt.RestartPolicy != podtemplate.RestartPolicyDefault ||
+		pt.DNSPolicy != podtemplate.DNSUnset || len(pt.SchedulerName) > 0 ||
+		pt.TerminationGracePeriod != nil || pt.ServiceLinks != nil {
+		t.Errorf("expected the pod's defaults to be left out, got %+v", pt)
+	}
+	if mode := mantlePod.Volumes["conf"].ConfigMap.DefaultMode; mode != nil {
+		t.Errorf("expected the volume's default mode to be left out, got %v", *mode)
+	}
+	c := mantlePod.Containers[0]
+	if c.Pull != container.PullDefault || len(c.TerminationMsgPath) > 0 ||
+		c.TerminationMsgPolicy != container.TerminationMessageReadFile {
+		t.Errorf("expected the container's defaults to be left out, got %+v", c)
+	}
+	if data, err := json.Marshal(c.Expose[0]); err != nil || strings.Contains(string(data), "Protocol") {
+		t.Errorf("expected the port's protocol to be left out, got %s", data)
+	}
+	if p := c.LivenessProbe; p.Interval != 0 || p.Timeout != 0 || p.MinCountSuccess != 0 || p.MinCountFailure != 0 {
+		t.Errorf("expected the probe's defaults to be left out, got %+v", p)
+	}
+
+	// ...and filled in again.
+	converted, err := ToKube(mantleObj)
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+	data, err := json.Marshal(converted)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling pod: %v", err)
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("unexpected error unmarshalling pod: %v", err)
+	}
+	delete(result, "status")
+	delete(result["metadata"].(map[string]interface{}), "creationTimestamp")
+
+	if expected := parseTree(t, defaultedPod); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+}
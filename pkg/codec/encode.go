@@ -1 +1,36 @@
 package codec
+
+import (
+	"mantle/pkg/core/configmap"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/util/objutil"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ToKube converts a mantle object into the matching kubernetes object.
+// Paths in the errors are relative to the mantle document's envelope.
+func ToKube(mantleObj interface{}) (runtime.Object, error) {
+	var kind string
+	var kubeObj runtime.Object
+	var err error
+
+	switch mantleTypedObj := mantleObj.(type) {
+	case *configmap.ConfigMap:
+		kind = KindConfigMap
+		kubeObj, err = mantleTypedObj.ToKube()
+	case *pod.Pod:
+		kind = KindPod
+		kubeObj, err = mantleTypedObj.ToKube()
+	default:
+		return nil, serrors.InvalidInstanceErrorf(mantleObj, "unsupported mantle type")
+	}
+
+	if err != nil {
+		return nil, objutil.ErrorAtPath(err, kind)
+	}
+
+	return kubeObj, nil
+}
@@ -0,0 +1,94 @@
+package codec
+
+import (
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	"mantle/pkg/core/configmap"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/util/objutil"
+)
+
+// Kinds of mantle objects. A mantle document wraps its object in an
+// envelope keyed by the object's kind, e.g.
+//
+//	pod:
+//	  name: broker
+//	  containers: ...
+const (
+	KindPod       = "pod"
+	KindConfigMap = "config_map"
+)
+
+// Kinds lists the kinds of mantle objects
+var Kinds = []string{KindPod, KindConfigMap}
+
+// NewMantleObject returns an empty mantle object of the given kind
+func NewMantleObject(kind string) (interface{}, error) {
+	switch kind {
+	case KindPod:
+		return &pod.Pod{}, nil
+	case KindConfigMap:
+		return &configmap.ConfigMap{}, nil
+	default:
+		return nil, serrors.InvalidValueErrorf(kind, "unknown mantle kind")
+	}
+}
+
+// IsMantleType reports whether obj is a mantle document, i.e. a
+// single-key envelope whose key is a known mantle kind.
+func IsMantleType(obj map[string]interface{}) bool {
+	if len(obj) != 1 {
+		return false
+	}
+
+	for kind := range obj {
+		for _, known := range Kinds {
+			if kind == known {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ParseMantleType unwraps the envelope of a mantle document and returns
+// the typed mantle object inside, e.g. *pod.Pod or *configmap.ConfigMap.
+func ParseMantleType(obj map[string]interface{}) (interface{}, error) {
+	if !IsMantleType(obj) {
+		return nil, serrors.InvalidValueErrorf(obj, "expected a single %s or %s key", KindPod, KindConfigMap)
+	}
+
+	for kind, body := range obj {
+		typedObj, err := NewMantleObject(kind)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, objutil.ErrorAtPath(err, kind)
+		}
+
+		if err := json.Unmarshal(data, typedObj); err != nil {
+			return nil, objutil.ErrorAtPath(err, kind)
+		}
+
+		return typedObj, nil
+	}
+
+	return nil, serrors.InvalidValueErrorf(obj, "empty mantle document")
+}
+
+// WrapMantleType puts a mantle object in the envelope for its kind
+func WrapMantleType(mantleObj interface{}) (map[string]interface{}, error) {
+	switch mantleObj.(type) {
+	case *pod.Pod:
+		return map[string]interface{}{KindPod: mantleObj}, nil
+	case *configmap.ConfigMap:
+		return map[string]interface{}{KindConfigMap: mantleObj}, nil
+	default:
+		return nil, serrors.InvalidInstanceErrorf(mantleObj, "unsupported mantle type")
+	}
+}
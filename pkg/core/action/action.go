@@ -9,11 +9,21 @@ const (
 	ActionTypeTCP
 )
 
+// Action defines a probe or lifecycle hook handler.
+// It's written as a URL (http, https or tcp) or as an exec command:
+//
+//	on_start: http://:8080/admin/v2/brokers/health
+//	pre_stop:
+//	  net: https://host:443/path
+//	  headers:
+//	  - X-Custom-Header:value
+//	liveness_probe:
+//	  exec: [bin/pulsar-admin, brokers, healthcheck]
 type Action struct {
-	ActionType ActionType `json:"actionType,omitempty"`
-	Command    []string   `json:"command,omitempty"`
-	Headers    []string   `json:"headers,omitempty"`
-	Host       string     `json:"host,omitempty"`
-	Port       string     `json:"host,omitempty"`
-	Path       string     `json:"path,omitempty"`
+	ActionType ActionType `json:"-"`
+	Command    []string   `json:"-"`
+	Headers    []string   `json:"-"`
+	Host       string     `json:"-"`
+	Port       string     `json:"-"`
+	Path       string     `json:"-"`
 }
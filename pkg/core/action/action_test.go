@@ -0,0 +1,103 @@
+package action
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+)
+
+func TestActionRoundTrip(t *testing.T) {
+	testcases := []struct {
+		description string
+		input       string
+		expected    Action
+	}{
+		{
+			description: "http url",
+			input:       `"http://:8080/admin/v2/brokers/health"`,
+			expected:    Action{ActionType: ActionTypeHTTP, Port: "8080", Path: "/admin/v2/brokers/health"},
+		},
+		{
+			description: "https url with host",
+			input:       `"https://pulsar.local:443/metrics"`,
+			expected:    Action{ActionType: ActionTypeHTTPS, Host: "pulsar.local", Port: "443", Path: "/metrics"},
+		},
+		{
+			description: "named port",
+			input:       `"http://:admin/status"`,
+			expected:    Action{ActionType: ActionTypeHTTP, Port: "admin", Path: "/status"},
+		},
+		{
+			description: "tcp url",
+			input:       `"tcp://:6650"`,
+			expected:    Action{ActionType: ActionTypeTCP, Port: "6650"},
+		},
+		{
+			description: "headers",
+			input:       `{"net":"https://:443/path","headers":["Authorization:Bearer a:b"]}`,
+			expected:    Action{ActionType: ActionTypeHTTPS, Port: "443", Path: "/path", Headers: []string{"Authorization:Bearer a:b"}},
+		},
+		{
+			description: "exec",
+			input:       `{"exec":["bin/pulsar-admin","brokers","healthcheck"]}`,
+			expected:    Action{ActionType: ActionTypeCommand, Command: []string{"bin/pulsar-admin", "brokers", "healthcheck"}},
+		},
+	}
+
+	for _, tc := range testcases {
+		a := Action{}
+		if err := json.Unmarshal([]byte(tc.input), &a); err != nil {
+			t.Errorf("%s: unmarshal failed with %v", tc.description, err)
+			continue
+		}
+		if !reflect.DeepEqual(a, tc.expected) {
+			t.Errorf("%s: expected %#v got %#v", tc.description, tc.expected, a)
+		}
+
+		out, err := json.Marshal(a)
+		if err != nil {
+			t.Errorf("%s: marshal failed with %v", tc.description, err)
+			continue
+		}
+		if string(out) != tc.input {
+			t.Errorf("%s: expected %s got %s", tc.description, tc.input, out)
+		}
+	}
+}
+
+func TestActionInvalidURL(t *testing.T) {
+	for _, input := range []string{`"ftp://:21"`, `"tcp://:6650/path"`, `"localhost:8080"`} {
+		a := Action{}
+		if err := json.Unmarshal([]byte(input), &a); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
+
+func TestActionKubeRoundTrip(t *testing.T) {
+	handler := &v1.Handler{
+		HTTPGet: &v1.HTTPGetAction{
+			Path: "/admin/v2/brokers/health",
+		},
+	}
+	handler.HTTPGet.Port.IntVal = 8080
+
+	a, err := fromKubeHandlerV1(handler)
+	if err != nil {
+		t.Fatalf("conversion failed with %v", err)
+	}
+	if a.ActionType != ActionTypeHTTP {
+		t.Errorf("empty scheme should be http, got %v", a.ActionType)
+	}
+
+	kubeHandler, err := a.toKubeV1()
+	if err != nil {
+		t.Fatalf("ToKube failed with %v", err)
+	}
+	if kubeHandler.HTTPGet.Port.IntValue() != 8080 || kubeHandler.HTTPGet.Path != handler.HTTPGet.Path {
+		t.Errorf("handler didn't round-trip: %#v", kubeHandler.HTTPGet)
+	}
+}
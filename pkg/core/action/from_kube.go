@@ -41,10 +41,8 @@ func fromKubeHandlerV1(handler *v1.Handler) (*Action, error) {
 			headers = append(headers, outHeader)
 		}
 
-		var actionType ActionType
-		if handler.HTTPGet.Scheme == v1.URISchemeHTTP {
-			actionType = ActionTypeHTTP
-		} else {
+		actionType := ActionTypeHTTP
+		if handler.HTTPGet.Scheme == v1.URISchemeHTTPS {
 			actionType = ActionTypeHTTPS
 		}
 
@@ -0,0 +1,150 @@
+package action
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+// Fields holds the long form of an Action. Types that embed an
+// Action (e.g. probes) use it to read and write the action fields
+// alongside their own.
+type Fields struct {
+	Exec    []string `json:"exec,omitempty"`
+	Net     string   `json:"net,omitempty"`
+	Headers []string `json:"headers,omitempty"`
+}
+
+var netURLRegexp = regexp.MustCompile(`^(?i)(http|https|tcp)://(\[[^\]]*\]|[^/:]*)(?::([^/]*))?(/.*)?$`)
+
+func schemeForActionType(actionType ActionType) (string, bool) {
+	switch actionType {
+	case ActionTypeHTTP:
+		return "http", true
+	case ActionTypeHTTPS:
+		return "https", true
+	case ActionTypeTCP:
+		return "tcp", true
+	default:
+		return "", false
+	}
+}
+
+// URL returns the URL shorthand for a net action, e.g. http://:8080/health
+func (a *Action) URL() (string, error) {
+	scheme, ok := schemeForActionType(a.ActionType)
+	if !ok {
+		return "", serrors.InvalidInstanceErrorf(a, "only http, https and tcp actions have a URL")
+	}
+
+	url := fmt.Sprintf("%s://%s", scheme, a.Host)
+	if len(a.Port) > 0 {
+		url = fmt.Sprintf("%s:%s", url, a.Port)
+	}
+
+	return url + a.Path, nil
+}
+
+// InitFromURL sets the action from a URL shorthand, e.g. tcp://:6650
+func (a *Action) InitFromURL(url string) error {
+	matches := netURLRegexp.FindStringSubmatch(strings.TrimSpace(url))
+	if matches == nil {
+		return serrors.InvalidValueErrorf(url, "expected http://host:port/path, https://host:port/path or tcp://host:port")
+	}
+
+	switch strings.ToLower(matches[1]) {
+	case "http":
+		a.ActionType = ActionTypeHTTP
+	case "https":
+		a.ActionType = ActionTypeHTTPS
+	case "tcp":
+		a.ActionType = ActionTypeTCP
+		if len(matches[4]) > 0 {
+			return serrors.InvalidValueErrorf(url, "tcp actions can't have a path")
+		}
+	}
+
+	a.Host = matches[2]
+	a.Port = matches[3]
+	a.Path = matches[4]
+	a.Command = nil
+
+	return nil
+}
+
+// ToFields returns the long form of the action.
+func (a *Action) ToFields() (*Fields, error) {
+	if a.ActionType == ActionTypeCommand {
+		return &Fields{Exec: a.Command}, nil
+	}
+
+	url, err := a.URL()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fields{Net: url, Headers: a.Headers}, nil
+}
+
+// InitFromFields sets the action from its long form.
+func (a *Action) InitFromFields(fields *Fields) error {
+	if len(fields.Exec) > 0 && len(fields.Net) > 0 {
+		return serrors.InvalidInstanceErrorf(fields, "only one of exec or net can be set")
+	}
+
+	if len(fields.Net) > 0 {
+		if err := a.InitFromURL(fields.Net); err != nil {
+			return serrors.ContextualizeErrorf(err, "net")
+		}
+		a.Headers = fields.Headers
+		return nil
+	}
+
+	if len(fields.Headers) > 0 {
+		return serrors.InvalidInstanceErrorf(fields, "headers are only allowed on net actions")
+	}
+
+	*a = Action{
+		ActionType: ActionTypeCommand,
+		Command:    fields.Exec,
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (a Action) MarshalJSON() ([]byte, error) {
+	fields, err := a.ToFields()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields.Net) > 0 && len(fields.Headers) == 0 {
+		return json.Marshal(fields.Net)
+	}
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (a *Action) UnmarshalJSON(data []byte) error {
+	var url string
+	if err := json.Unmarshal(data, &url); err == nil {
+		return a.InitFromURL(url)
+	}
+
+	var command []string
+	if err := json.Unmarshal(data, &command); err == nil {
+		return a.InitFromFields(&Fields{Exec: command})
+	}
+
+	fields := Fields{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return serrors.InvalidValueForTypeErrorf(string(data), a, "expected a URL, a command or {exec|net, headers}")
+	}
+
+	return a.InitFromFields(&fields)
+}
@@ -33,22 +33,21 @@ func (a *Action) toKubeV1() (*v1.Handler, error) {
 		}
 
 	case ActionTypeHTTP, ActionTypeHTTPS:
-		var scheme v1.URIScheme
+		scheme := v1.URISchemeHTTP
+		port := intstr.FromInt(80)
 
-		if a.ActionType == ActionTypeHTTP {
-			scheme = v1.URISchemeHTTP
-		} else {
+		if a.ActionType == ActionTypeHTTPS {
 			scheme = v1.URISchemeHTTPS
+			port = intstr.FromInt(443)
 		}
 
-		port := intstr.FromString("80")
 		if len(a.Port) > 0 {
 			port = intstr.Parse(a.Port)
 		}
 
 		var headers []v1.HTTPHeader
 		for _, header := range a.Headers {
-			fields := strings.Split(header, ":")
+			fields := strings.SplitN(header, ":", 2)
 			if len(fields) != 2 {
 				return nil, serrors.InvalidInstanceErrorf(a, "unexpected HTTP Header %s", header)
 			}
@@ -68,7 +67,7 @@ func (a *Action) toKubeV1() (*v1.Handler, error) {
 		}
 
 	case ActionTypeTCP:
-		port := intstr.FromString("80")
+		port := intstr.FromInt(80)
 		if len(a.Port) > 0 {
 			port = intstr.Parse(a.Port)
 		}
@@ -0,0 +1,31 @@
+package action
+
+import (
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the action
+func (a *Action) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	switch a.ActionType {
+	case ActionTypeCommand:
+		if len(a.Command) == 0 {
+			errs = append(errs, field.Required(field.NewPath("exec"), "command must not be empty"))
+		}
+	case ActionTypeHTTP, ActionTypeHTTPS, ActionTypeTCP:
+		if len(a.Command) > 0 {
+			errs = append(errs, field.Invalid(field.NewPath("exec"), a.Command, "net actions can't have a command"))
+		}
+		if len(a.Port) == 0 {
+			errs = append(errs, field.Required(field.NewPath("net"), "port must be set"))
+		}
+		errs = append(errs, validation.Port(field.NewPath("net"), a.Port, true)...)
+	default:
+		errs = append(errs, field.NotSupported(nil, a.ActionType, []string{"exec", "http", "https", "tcp"}))
+	}
+
+	return errs
+}
@@ -1,13 +1,19 @@
 package configmap
 
+import (
+	"mantle/pkg/core/meta"
+	"mantle/pkg/util/extra"
+)
+
 // ConfigMap defines a config map object
 type ConfigMap struct {
-	Version     string            `json:"version,omitempty"`
-	Cluster     string            `json:"cluster,omitempty"`
-	Name        string            `json:"name,omitempty"`
-	Namespace   string            `json:"namespace,omitempty"`
-	Labels      map[string]string `json:"labels,omitempty"`
-	Annotations map[string]string `json:"annotations,omitempty"`
-	Data        map[string]string `json:"data,omitempty"`
-	BinaryData  map[string][]byte `json:"binaryData,omitempty"`
+	Version   string `json:"version,omitempty"`
+	meta.Meta `json:",inline"`
+
+	Data       map[string]string `json:"data,omitempty"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+
+	// Extra holds the fields of the kubernetes config map that mantle
+	// doesn't model. It's merged into the config map mantle generates.
+	Extra extra.Extra `json:"extra,omitempty"`
 }
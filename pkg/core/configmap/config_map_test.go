@@ -4,6 +4,9 @@ import (
 	"reflect"
 	"testing"
 
+	"mantle/pkg/core/meta"
+	"mantle/pkg/util/extra"
+
 	"k8s.io/api/core/v1"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -116,14 +119,16 @@ func TestToKube(t *testing.T) {
 
 func TestToKubeV1(t *testing.T) {
 	cm := ConfigMap{
-		Version:     "v1",
-		Name:        "testCM",
-		Namespace:   "testNS",
-		Cluster:     "testCluster",
-		Labels:      map[string]string{"label1": "test1", "label2": "test2"},
-		Annotations: map[string]string{"ann1": "test1", "ann2": "test2"},
-		Data:        map[string]string{"field1": "data1", "field2": "data2"},
-		BinaryData:  map[string][]byte{"bfield1": []byte("bdata1")},
+		Version: "v1",
+		Meta: meta.Meta{
+			Name:        "testCM",
+			Namespace:   "testNS",
+			Cluster:     "testCluster",
+			Labels:      map[string]string{"label1": "test1", "label2": "test2"},
+			Annotations: map[string]string{"ann1": "test1", "ann2": "test2"},
+		},
+		Data:       map[string]string{"field1": "data1", "field2": "data2"},
+		BinaryData: map[string][]byte{"bfield1": []byte("bdata1")},
 	}
 
 	kubeObj, _ := cm.toKubeV1()
@@ -135,3 +140,40 @@ func TestToKubeV1(t *testing.T) {
 		}
 	}
 }
+
+func TestExtra(t *testing.T) {
+	gracePeriod := int64(30)
+	v1CM := &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:                       "testCM",
+			DeletionGracePeriodSeconds: &gracePeriod,
+		},
+		Data: map[string]string{"field1": "data1"},
+	}
+
+	cm, err := fromKubeConfigMapV1(v1CM)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedExtra := extra.Extra{
+		"metadata": map[string]interface{}{
+			"deletionGracePeriodSeconds": float64(30),
+		},
+	}
+	if !reflect.DeepEqual(cm.Extra, expectedExtra) {
+		t.Errorf("incorrect extra, expected %v, got %v", expectedExtra, cm.Extra)
+	}
+
+	kubeObj, err := cm.ToKube()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(kubeObj, v1CM) {
+		t.Errorf("incorrect round trip, expected %v, got %v", v1CM, kubeObj)
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"fmt"
 	"reflect"
 
+	"mantle/pkg/core/meta"
+	"mantle/pkg/util/extra"
+	"mantle/pkg/util/objutil"
+
 	"k8s.io/api/core/v1"
 )
 
@@ -22,15 +26,25 @@ func NewConfigMapFromKubeConfigMap(cm interface{}) (*ConfigMap, error) {
 }
 
 func fromKubeConfigMapV1(kubeConfigMap *v1.ConfigMap) (*ConfigMap, error) {
+	objectMeta, err := meta.NewMetaFromKubeObjectMeta(kubeConfigMap.ObjectMeta)
+	if err != nil {
+		return nil, objutil.ErrorAtPath(err, "metadata")
+	}
+
 	cm := &ConfigMap{
-		Name:        kubeConfigMap.Name,
-		Namespace:   kubeConfigMap.Namespace,
-		Version:     kubeConfigMap.APIVersion,
-		Cluster:     kubeConfigMap.ClusterName,
-		Labels:      kubeConfigMap.Labels,
-		Annotations: kubeConfigMap.Annotations,
-		Data:        kubeConfigMap.Data,
-		BinaryData:  kubeConfigMap.BinaryData,
+		Version:    kubeConfigMap.APIVersion,
+		Meta:       *objectMeta,
+		Data:       kubeConfigMap.Data,
+		BinaryData: kubeConfigMap.BinaryData,
+	}
+
+	// A config map that can't be converted back keeps no extra fields,
+	// as for pods.
+	if generated, err := cm.toKubeV1(); err == nil {
+		cm.Extra, err = extra.Unmapped(kubeConfigMap, generated)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return cm, nil
@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"strings"
 
+	"mantle/pkg/util/objutil"
+
 	"k8s.io/api/core/v1"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -25,15 +28,20 @@ func (cm *ConfigMap) ToKube() (runtime.Object, error) {
 func (cm *ConfigMap) toKubeV1() (*v1.ConfigMap, error) {
 	kubeConfigMap := &v1.ConfigMap{}
 
-	kubeConfigMap.Name = cm.Name
-	kubeConfigMap.Namespace = cm.Namespace
+	objectMeta, err := cm.Meta.ToKube(cm.Version)
+	if err != nil {
+		return nil, err
+	}
+	kubeConfigMap.ObjectMeta = *objectMeta.(*metav1.ObjectMeta)
+
 	kubeConfigMap.APIVersion = cm.Version
-	kubeConfigMap.ClusterName = cm.Cluster
 	kubeConfigMap.Kind = "ConfigMap"
-	kubeConfigMap.Labels = cm.Labels
-	kubeConfigMap.Annotations = cm.Annotations
 	kubeConfigMap.Data = cm.Data
 	kubeConfigMap.BinaryData = cm.BinaryData
 
+	if err := cm.Extra.Apply(kubeConfigMap); err != nil {
+		return nil, objutil.ErrorAtPath(err, "extra")
+	}
+
 	return kubeConfigMap, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,45 @@
+package configmap
+
+import (
+	"sort"
+
+	kubevalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the config map
+func (cm *ConfigMap) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, cm.Meta.Validate()...)
+
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, msg := range kubevalidation.IsConfigMapKey(key) {
+			errs = append(errs, field.Invalid(field.NewPath("data").Key(key), key, msg))
+		}
+	}
+
+	binaryKeys := make([]string, 0, len(cm.BinaryData))
+	for key := range cm.BinaryData {
+		binaryKeys = append(binaryKeys, key)
+	}
+	sort.Strings(binaryKeys)
+
+	for _, key := range binaryKeys {
+		fldPath := field.NewPath("binaryData").Key(key)
+		for _, msg := range kubevalidation.IsConfigMapKey(key) {
+			errs = append(errs, field.Invalid(fldPath, key, msg))
+		}
+		if _, ok := cm.Data[key]; ok {
+			errs = append(errs, field.Duplicate(fldPath, key))
+		}
+	}
+
+	return errs
+}
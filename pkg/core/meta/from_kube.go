@@ -0,0 +1,59 @@
+package meta
+
+import (
+	"fmt"
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewMetaFromKubeObjectMeta will create a new Meta object with
+// the data from a provided kubernetes ObjectMeta object
+func NewMetaFromKubeObjectMeta(obj interface{}) (*Meta, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(metav1.ObjectMeta{}):
+		return fromKubeObjectMetaV1(obj.(metav1.ObjectMeta))
+	case reflect.TypeOf(&metav1.ObjectMeta{}):
+		o := obj.(*metav1.ObjectMeta)
+		return fromKubeObjectMetaV1(*o)
+	default:
+		return nil, fmt.Errorf("unknown ObjectMeta version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromKubeObjectMetaV1(meta metav1.ObjectMeta) (*Meta, error) {
+	m := &Meta{
+		Name:            meta.Name,
+		GenerateName:    meta.GenerateName,
+		Namespace:       meta.Namespace,
+		Cluster:         meta.ClusterName,
+		Labels:          meta.Labels,
+		Annotations:     meta.Annotations,
+		Finalizers:      meta.Finalizers,
+		UID:             string(meta.UID),
+		ResourceVersion: meta.ResourceVersion,
+		Deleted:         meta.DeletionTimestamp,
+	}
+
+	if !meta.CreationTimestamp.IsZero() {
+		created := meta.CreationTimestamp
+		m.Created = &created
+	}
+
+	for _, ref := range meta.OwnerReferences {
+		m.OwnerRefs = append(m.OwnerRefs, fromKubeOwnerReferenceV1(ref))
+	}
+
+	return m, nil
+}
+
+func fromKubeOwnerReferenceV1(ref metav1.OwnerReference) OwnerRef {
+	return OwnerRef{
+		Version:       ref.APIVersion,
+		Kind:          ref.Kind,
+		Name:          ref.Name,
+		UID:           string(ref.UID),
+		Controller:    ref.Controller != nil && *ref.Controller,
+		BlockDeletion: ref.BlockOwnerDeletion != nil && *ref.BlockOwnerDeletion,
+	}
+}
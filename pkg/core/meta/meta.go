@@ -0,0 +1,41 @@
+package meta
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Meta defines the metadata of a mantle object
+//
+// UID, ResourceVersion and the timestamps are set by kubernetes. They're
+// kept when converting from kubernetes, and dropped by decoding with
+// --clean. The UID is written as object_uid, since uid is the user a
+// pod's containers run as.
+type Meta struct {
+	Cluster         string            `json:"cluster,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	GenerateName    string            `json:"generate_name,omitempty"`
+	Namespace       string            `json:"namespace,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+	OwnerRefs       []OwnerRef        `json:"owner_refs,omitempty"`
+	Finalizers      []string          `json:"finalizers,omitempty"`
+	UID             string            `json:"object_uid,omitempty"`
+	ResourceVersion string            `json:"resource_version,omitempty"`
+	Created         *metav1.Time      `json:"created,omitempty"`
+	Deleted         *metav1.Time      `json:"deleted,omitempty"`
+}
+
+// OwnerRef refers to an object that owns this one. It's written in full
+// as an object, or as kind/name, e.g. StatefulSet/bookie, optionally
+// followed by controller if the owner is the managing controller.
+// Kubernetes needs the owner's version and uid as well, which the
+// kind/name form doesn't have, so it's only for sketching an owner;
+// Validate reports it.
+type OwnerRef struct {
+	Version       string `json:"version,omitempty"`
+	Kind          string `json:"kind,omitempty"`
+	Name          string `json:"name,omitempty"`
+	UID           string `json:"uid,omitempty"`
+	Controller    bool   `json:"controller,omitempty"`
+	BlockDeletion bool   `json:"block_deletion,omitempty"`
+}
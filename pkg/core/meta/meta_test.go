@@ -0,0 +1,98 @@
+package meta
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/koki/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOwnerRefRoundTrip(t *testing.T) {
+	testcases := []struct {
+		description string
+		input       string
+		expected    OwnerRef
+	}{
+		{
+			description: "kind/name",
+			input:       `"StatefulSet/bookie"`,
+			expected:    OwnerRef{Kind: "StatefulSet", Name: "bookie"},
+		},
+		{
+			description: "controller",
+			input:       `"StatefulSet/bookie controller"`,
+			expected:    OwnerRef{Kind: "StatefulSet", Name: "bookie", Controller: true},
+		},
+		{
+			description: "full",
+			input:       `{"version":"apps/v1","kind":"StatefulSet","name":"bookie","uid":"1234","controller":true}`,
+			expected:    OwnerRef{Version: "apps/v1", Kind: "StatefulSet", Name: "bookie", UID: "1234", Controller: true},
+		},
+	}
+
+	for _, tc := range testcases {
+		r := OwnerRef{}
+		if err := json.Unmarshal([]byte(tc.input), &r); err != nil {
+			t.Errorf("%s: unmarshal failed with %v", tc.description, err)
+			continue
+		}
+		if !reflect.DeepEqual(r, tc.expected) {
+			t.Errorf("%s: expected %#v got %#v", tc.description, tc.expected, r)
+		}
+
+		out, err := json.Marshal(r)
+		if err != nil {
+			t.Errorf("%s: marshal failed with %v", tc.description, err)
+			continue
+		}
+		if string(out) != tc.input {
+			t.Errorf("%s: expected %s got %s", tc.description, tc.input, out)
+		}
+	}
+}
+
+func TestOwnerRefInvalid(t *testing.T) {
+	for _, input := range []string{`"bookie"`, `"StatefulSet/"`, `"StatefulSet/bookie owner"`} {
+		r := OwnerRef{}
+		if err := json.Unmarshal([]byte(input), &r); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
+
+func TestMetaKubeRoundTrip(t *testing.T) {
+	controller := true
+	created := metav1.NewTime(time.Date(2019, 2, 1, 12, 0, 0, 0, time.UTC))
+	kubeMeta := metav1.ObjectMeta{
+		Name:              "bookie-0",
+		GenerateName:      "bookie-",
+		Namespace:         "pulsar",
+		Labels:            map[string]string{"app": "bookie"},
+		Finalizers:        []string{"pulsar.apache.org/cleanup"},
+		UID:               "5678",
+		ResourceVersion:   "42",
+		CreationTimestamp: created,
+		OwnerReferences: []metav1.OwnerReference{
+			{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "bookie", UID: "1234", Controller: &controller},
+		},
+	}
+
+	m, err := NewMetaFromKubeObjectMeta(kubeMeta)
+	if err != nil {
+		t.Fatalf("conversion failed with %v", err)
+	}
+	if m.Created == nil || !m.Created.Equal(&created) {
+		t.Errorf("expected created %v, got %v", created, m.Created)
+	}
+
+	out, err := m.toKubeV1()
+	if err != nil {
+		t.Fatalf("ToKube failed with %v", err)
+	}
+	if !reflect.DeepEqual(*out, kubeMeta) {
+		t.Errorf("metadata didn't round-trip: expected %#v got %#v", kubeMeta, *out)
+	}
+}
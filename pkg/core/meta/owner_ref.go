@@ -0,0 +1,76 @@
+package meta
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+const controllerFlag = "controller"
+
+type ownerRefFields OwnerRef
+
+// String returns the kind/name form of the owner reference, followed by
+// controller if it's the managing controller
+func (r *OwnerRef) String() string {
+	if r.Controller {
+		return fmt.Sprintf("%s/%s %s", r.Kind, r.Name, controllerFlag)
+	}
+	return fmt.Sprintf("%s/%s", r.Kind, r.Name)
+}
+
+// InitFromString sets the owner reference from its kind/name form
+func (r *OwnerRef) InitFromString(str string) error {
+	segments := strings.Fields(str)
+	if len(segments) == 0 || len(segments) > 2 {
+		return serrors.InvalidValueErrorf(str, "expected kind/name, optionally followed by %s", controllerFlag)
+	}
+
+	fields := strings.SplitN(segments[0], "/", 2)
+	if len(fields) != 2 || len(fields[0]) == 0 || len(fields[1]) == 0 {
+		return serrors.InvalidValueErrorf(str, "expected kind/name")
+	}
+
+	*r = OwnerRef{Kind: fields[0], Name: fields[1]}
+
+	if len(segments) == 2 {
+		if segments[1] != controllerFlag {
+			return serrors.InvalidValueErrorf(segments[1], "expected %s", controllerFlag)
+		}
+		r.Controller = true
+	}
+
+	return nil
+}
+
+// isCompact reports whether the owner reference can be written in
+// its kind/name form
+func (r *OwnerRef) isCompact() bool {
+	return len(r.Version) == 0 && len(r.UID) == 0 && !r.BlockDeletion
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (r OwnerRef) MarshalJSON() ([]byte, error) {
+	if r.isCompact() {
+		return json.Marshal(r.String())
+	}
+	return json.Marshal(ownerRefFields(r))
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (r *OwnerRef) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return r.InitFromString(str)
+	}
+
+	fields := ownerRefFields{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return serrors.InvalidValueForTypeErrorf(string(data), r, "expected kind/name or {version, kind, name, uid, controller, block_deletion}")
+	}
+
+	*r = OwnerRef(fields)
+	return nil
+}
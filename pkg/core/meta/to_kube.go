@@ -0,0 +1,79 @@
+package meta
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ToKube converts the Meta object to a
+// kubernetes meta object of the version specified
+func (m *Meta) ToKube(version string) (interface{}, error) {
+	switch strings.ToLower(version) {
+	case "v1":
+		return m.toKubeV1()
+	case "":
+		return m.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for Meta: %s", version)
+	}
+}
+
+func (m *Meta) toKubeV1() (*metav1.ObjectMeta, error) {
+	var labels map[string]string
+	var annotations map[string]string
+
+	if len(m.Labels) > 0 {
+		labels = m.Labels
+	}
+
+	if len(m.Annotations) > 0 {
+		annotations = m.Annotations
+	}
+
+	kubeMeta := &metav1.ObjectMeta{
+		Name:              m.Name,
+		GenerateName:      m.GenerateName,
+		Namespace:         m.Namespace,
+		ClusterName:       m.Cluster,
+		Labels:            labels,
+		Annotations:       annotations,
+		Finalizers:        m.Finalizers,
+		UID:               types.UID(m.UID),
+		ResourceVersion:   m.ResourceVersion,
+		DeletionTimestamp: m.Deleted,
+	}
+
+	if m.Created != nil {
+		kubeMeta.CreationTimestamp = *m.Created
+	}
+
+	for _, ref := range m.OwnerRefs {
+		kubeMeta.OwnerReferences = append(kubeMeta.OwnerReferences, ref.toKubeV1())
+	}
+
+	return kubeMeta, nil
+}
+
+func (r *OwnerRef) toKubeV1() metav1.OwnerReference {
+	ref := metav1.OwnerReference{
+		APIVersion: r.Version,
+		Kind:       r.Kind,
+		Name:       r.Name,
+		UID:        types.UID(r.UID),
+	}
+
+	if r.Controller {
+		controller := true
+		ref.Controller = &controller
+	}
+
+	if r.BlockDeletion {
+		blockDeletion := true
+		ref.BlockOwnerDeletion = &blockDeletion
+	}
+
+	return ref
+}
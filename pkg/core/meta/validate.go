@@ -0,0 +1,49 @@
+package meta
+
+import (
+	"strings"
+
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the metadata. The object needs a
+// name, or a generate_name that kubernetes makes one from.
+func (m *Meta) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	if len(m.Name) == 0 && len(m.GenerateName) == 0 {
+		errs = append(errs, field.Required(field.NewPath("name"), "name or generate_name must be set"))
+	}
+	errs = append(errs, validation.DNS1123Subdomain(field.NewPath("name"), m.Name)...)
+	// Kubernetes appends a random suffix, usually after a trailing '-'.
+	errs = append(errs, validation.DNS1123Subdomain(field.NewPath("generate_name"), strings.TrimSuffix(m.GenerateName, "-"))...)
+	errs = append(errs, validation.DNS1123Label(field.NewPath("namespace"), m.Namespace)...)
+	errs = append(errs, validation.Labels(field.NewPath("labels"), m.Labels)...)
+	errs = append(errs, validation.Annotations(field.NewPath("annotations"), m.Annotations)...)
+
+	controllers := 0
+	for i, ref := range m.OwnerRefs {
+		fldPath := field.NewPath("owner_refs").Index(i)
+		errs = append(errs, validation.Required(fldPath.Child("kind"), ref.Kind)...)
+		errs = append(errs, validation.Required(fldPath.Child("name"), ref.Name)...)
+		// Kubernetes needs both to find the owner, so the kind/name
+		// form can't be applied on its own.
+		errs = append(errs, validation.Required(fldPath.Child("version"), ref.Version)...)
+		errs = append(errs, validation.Required(fldPath.Child("uid"), ref.UID)...)
+
+		if ref.Controller {
+			controllers++
+			if controllers > 1 {
+				errs = append(errs, field.Invalid(fldPath.Child("controller"), true, "only one owner can be the controller"))
+			}
+		}
+	}
+
+	for i, finalizer := range m.Finalizers {
+		errs = append(errs, validation.QualifiedName(field.NewPath("finalizers").Index(i), finalizer)...)
+	}
+
+	return errs
+}
@@ -0,0 +1,54 @@
+package meta
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateMeta(t *testing.T) {
+	testcases := []struct {
+		description string
+		meta        Meta
+		expected    []string
+	}{
+		{
+			description: "name",
+			meta:        Meta{Name: "bookie-0"},
+		},
+		{
+			description: "generate_name",
+			meta:        Meta{GenerateName: "bookie-"},
+		},
+		{
+			description: "no name",
+			meta:        Meta{Namespace: "pulsar"},
+			expected:    []string{"Required value name"},
+		},
+		{
+			description: "invalid generate_name",
+			meta:        Meta{GenerateName: "Bookie-"},
+			expected:    []string{"Invalid value generate_name"},
+		},
+		{
+			description: "full owner",
+			meta: Meta{Name: "bookie-0", OwnerRefs: []OwnerRef{
+				{Version: "apps/v1", Kind: "StatefulSet", Name: "bookie", UID: "1234", Controller: true},
+			}},
+		},
+		{
+			description: "kind/name owner",
+			meta:        Meta{Name: "bookie-0", OwnerRefs: []OwnerRef{{Kind: "StatefulSet", Name: "bookie"}}},
+			expected:    []string{"Required value owner_refs[0].version", "Required value owner_refs[0].uid"},
+		},
+	}
+
+	for _, tc := range testcases {
+		var fields []string
+		for _, err := range tc.meta.Validate() {
+			fields = append(fields, err.Type.String()+" "+err.Field)
+		}
+		if !reflect.DeepEqual(fields, tc.expected) {
+			t.Errorf("%s: expected errors %v, got %v", tc.description, tc.expected, fields)
+		}
+	}
+}
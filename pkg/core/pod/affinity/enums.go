@@ -0,0 +1,15 @@
+package affinity
+
+import (
+	"mantle/pkg/util/enum"
+)
+
+//go:generate go run mantle/internal/enumgen
+
+// The names that enums are written as. See enum.Names.
+// The methods that read and write them are generated from them.
+var (
+	affinityTypeNames     = enum.Names{"hard", "soft"}
+	selectorOperatorNames = enum.Names{"in", "not-in", "exists", "does-not-exist"}
+	nodeOperatorNames     = enum.Names{"in", "not-in", "exists", "does-not-exist", "gt", "lt"}
+)
@@ -0,0 +1,93 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package affinity
+
+// EnumNames returns the names of the AffinityType values
+func (AffinityType) EnumNames() []string {
+	return affinityTypeNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (a AffinityType) MarshalText() ([]byte, error) {
+	return affinityTypeNames.Text(int(a))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (a *AffinityType) UnmarshalText(text []byte) error {
+	value, err := affinityTypeNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*a = AffinityType(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (a *AffinityType) UnmarshalJSON(data []byte) error {
+	value, err := affinityTypeNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*a = AffinityType(value)
+	return nil
+}
+
+// EnumNames returns the names of the SelectorOperator values
+func (SelectorOperator) EnumNames() []string {
+	return selectorOperatorNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s SelectorOperator) MarshalText() ([]byte, error) {
+	return selectorOperatorNames.Text(int(s))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *SelectorOperator) UnmarshalText(text []byte) error {
+	value, err := selectorOperatorNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*s = SelectorOperator(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (s *SelectorOperator) UnmarshalJSON(data []byte) error {
+	value, err := selectorOperatorNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*s = SelectorOperator(value)
+	return nil
+}
+
+// EnumNames returns the names of the NodeOperator values
+func (NodeOperator) EnumNames() []string {
+	return nodeOperatorNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (n NodeOperator) MarshalText() ([]byte, error) {
+	return nodeOperatorNames.Text(int(n))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (n *NodeOperator) UnmarshalText(text []byte) error {
+	value, err := nodeOperatorNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*n = NodeOperator(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (n *NodeOperator) UnmarshalJSON(data []byte) error {
+	value, err := nodeOperatorNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*n = NodeOperator(value)
+	return nil
+}
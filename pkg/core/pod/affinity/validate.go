@@ -0,0 +1,129 @@
+package affinity
+
+import (
+	"fmt"
+	"strconv"
+
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the affinity
+func (a *Affinity) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	for _, typ := range []AffinityType{AffinityHard, AffinitySoft} {
+		for i, term := range a.NodeAffinity[typ] {
+			fldPath := field.NewPath("node").Key(affinityTypeName(typ)).Index(i)
+			errs = append(errs, validation.Prefix(fldPath, term.validate(typ))...)
+		}
+		for i, term := range a.PodAffinity[typ] {
+			fldPath := field.NewPath("pod").Key(affinityTypeName(typ)).Index(i)
+			errs = append(errs, validation.Prefix(fldPath, term.validate(typ))...)
+		}
+		for i, term := range a.PodAntiAffinity[typ] {
+			fldPath := field.NewPath("antiPod").Key(affinityTypeName(typ)).Index(i)
+			errs = append(errs, validation.Prefix(fldPath, term.validate(typ))...)
+		}
+	}
+
+	return errs
+}
+
+// affinityTypeName is the key of the affinity type in the term maps
+func affinityTypeName(typ AffinityType) string {
+	return strconv.Itoa(int(typ))
+}
+
+func validateWeight(typ AffinityType, weight int32) field.ErrorList {
+	fldPath := field.NewPath("weight")
+
+	if typ == AffinityHard && weight != 0 {
+		return field.ErrorList{field.Invalid(fldPath, weight, "only soft affinity terms have a weight")}
+	}
+	if typ == AffinitySoft && (weight < 1 || weight > 100) {
+		return field.ErrorList{field.Invalid(fldPath, weight, "must be between 1 and 100")}
+	}
+
+	return nil
+}
+
+func (t *NodeTerm) validate(typ AffinityType) field.ErrorList {
+	errs := validateWeight(typ, t.Weight)
+
+	for i, expr := range t.Expressions {
+		errs = append(errs, validation.Prefix(field.NewPath("expression").Index(i), expr.validate())...)
+	}
+	for i, expr := range t.Fields {
+		errs = append(errs, validation.Prefix(field.NewPath("field").Index(i), expr.validate())...)
+	}
+
+	return errs
+}
+
+func (e *NodeExpression) validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validation.Required(field.NewPath("key"), e.Key)...)
+	errs = append(errs, validation.QualifiedName(field.NewPath("key"), e.Key)...)
+
+	switch e.Op {
+	case NodeOperatorIn, NodeOperatorNotIn:
+		if len(e.Values) == 0 {
+			errs = append(errs, field.Required(field.NewPath("values"), "must be set for In and NotIn"))
+		}
+	case NodeOperatorExists, NodeOperatorDoesNotExist:
+		if len(e.Values) > 0 {
+			errs = append(errs, field.Forbidden(field.NewPath("values"), "must be empty for Exists and DoesNotExist"))
+		}
+	case NodeOperatorGt, NodeOperatorLt:
+		if len(e.Values) != 1 {
+			errs = append(errs, field.Invalid(field.NewPath("values"), e.Values, "must have a single value for Gt and Lt"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("op"), e.Op, nil))
+	}
+
+	return errs
+}
+
+func (t *PodTerm) validate(typ AffinityType) field.ErrorList {
+	errs := validateWeight(typ, t.Weight)
+
+	errs = append(errs, validation.Required(field.NewPath("topology"), t.Topology)...)
+	errs = append(errs, validation.QualifiedName(field.NewPath("topology"), t.Topology)...)
+	errs = append(errs, validation.Prefix(field.NewPath("selector"), t.Selector.validate())...)
+
+	for i, namespace := range t.Namespaces {
+		errs = append(errs, validation.DNS1123Label(field.NewPath("namespaces").Index(i), namespace)...)
+	}
+
+	return errs
+}
+
+func (s *Selector) validate() field.ErrorList {
+	errs := validation.Labels(field.NewPath("labels"), s.Labels)
+
+	for i, expr := range s.Expressions {
+		fldPath := field.NewPath("expression").Index(i)
+
+		errs = append(errs, validation.Required(fldPath.Child("key"), expr.Key)...)
+		errs = append(errs, validation.QualifiedName(fldPath.Child("key"), expr.Key)...)
+
+		switch expr.Op {
+		case SelectorOperatorIn, SelectorOperatorNotIn:
+			if len(expr.Values) == 0 {
+				errs = append(errs, field.Required(fldPath.Child("values"), "must be set for In and NotIn"))
+			}
+		case SelectorOperatorExists, SelectorOperatorDoesNotExist:
+			if len(expr.Values) > 0 {
+				errs = append(errs, field.Forbidden(fldPath.Child("values"), "must be empty for Exists and DoesNotExist"))
+			}
+		default:
+			errs = append(errs, field.NotSupported(fldPath.Child("op"), fmt.Sprint(expr.Op), nil))
+		}
+	}
+
+	return errs
+}
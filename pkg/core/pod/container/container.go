@@ -8,48 +8,59 @@ import (
 	"mantle/pkg/core/pod/container/resources"
 	"mantle/pkg/core/pod/container/volumemount"
 	"mantle/pkg/core/selinux"
+	"mantle/pkg/util/extra"
 	"mantle/pkg/util/floatstr"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type Container struct {
-	Command              []string                  `json:"command,omitempty"`
-	Args                 []floatstr.FloatOrString  `json:"args,omitempty"`
-	Env                  []env.Env                 `json:"env,omitempty"`
-	Image                string                    `json:"image"`
-	Pull                 PullPolicy                `json:"pull,omitempty"`
-	OnStart              *action.Action            `json:"on_start,omitempty"`
-	PreStop              *action.Action            `json:"pre_stop,omitempty"`
-	CPU                  *resources.CPU            `json:"cpu,omitempty"`
-	Mem                  *resources.Mem            `json:"mem,omitempty"`
-	Name                 string                    `json:"name,omitempty"`
-	AddCapabilities      []string                  `json:"cap_add,omitempty"`
-	DelCapabilities      []string                  `json:"cap_drop,omitempty"`
-	Privileged           *bool                     `json:"privileged,omitempty"`
-	AllowEscalation      *bool                     `json:"allow_escalation,omitempty"`
-	RW                   *bool                     `json:"rw,omitempty"`
-	RO                   *bool                     `json:"ro,omitempty"`
-	ForceNonRoot         *bool                     `json:"force_non_root,omitempty"`
-	UID                  *int64                    `json:"uid,omitempty"`
-	GID                  *int64                    `json:"gid,omitempty"`
-	SELinux              *selinux.SELinux          `json:"selinux,omitempty"`
-	LivenessProbe        *probe.Probe              `json:"liveness_probe,omitempty"`
-	ReadinessProbe       *probe.Probe              `json:"readiness_probe,omitempty"`
-	Expose               []port.Port               `json:"expose,omitempty"`
-	Stdin                bool                      `json:"stdin,omitempty"`
-	StdinOnce            bool                      `json:"stdin_once,omitempty"`
-	TTY                  bool                      `json:"tty,omitempty"`
-	WorkingDir           string                    `json:"wd,omitempty"`
-	TerminationMsgPath   string                    `json:"termination_msg_path,omitempty"`
-	TerminationMsgPolicy TerminationMessagePolicy  `json:"termination_msg_policy,omitempty"`
-	ContainerID          string                    `json:"container_id,omitempty"`
-	ImageID              string                    `json:"image_id,omitempty"`
-	Ready                bool                      `json:"ready,omitempty"`
-	LastState            *ContainerState           `json:"last_state,omitempty"`
-	CurrentState         *ContainerState           `json:"current_state,omitempty"`
-	VolumeMounts         []volumemount.VolumeMount `json:"volume,omitempty"`
-	Restarts             int32                     `json:"restarts,omitempty"`
+	Command              []string                    `json:"command,omitempty"`
+	Args                 []floatstr.FloatOrString    `json:"args,omitempty"`
+	Env                  []env.Env                   `json:"env,omitempty"`
+	Image                string                      `json:"image"`
+	Pull                 PullPolicy                  `json:"pull,omitempty"`
+	OnStart              *action.Action              `json:"on_start,omitempty"`
+	PreStop              *action.Action              `json:"pre_stop,omitempty"`
+	CPU                  *resources.CPU              `json:"cpu,omitempty"`
+	Mem                  *resources.Mem              `json:"mem,omitempty"`
+	Storage              *resources.Storage          `json:"ephemeral_storage,omitempty"`
+	Extended             resources.ExtendedResources `json:"extended_resources,omitempty"`
+	Name                 string                      `json:"name,omitempty"`
+	AddCapabilities      []string                    `json:"cap_add,omitempty"`
+	DelCapabilities      []string                    `json:"cap_drop,omitempty"`
+	Privileged           *bool                       `json:"privileged,omitempty"`
+	AllowEscalation      *bool                       `json:"allow_escalation,omitempty"`
+	RW                   *bool                       `json:"rw,omitempty"`
+	RO                   *bool                       `json:"ro,omitempty"`
+	ForceNonRoot         *bool                       `json:"force_non_root,omitempty"`
+	UID                  *int64                      `json:"uid,omitempty"`
+	GID                  *int64                      `json:"gid,omitempty"`
+	SELinux              *selinux.SELinux            `json:"selinux,omitempty"`
+	Seccomp              string                      `json:"seccomp,omitempty"`
+	AppArmor             string                      `json:"apparmor,omitempty"`
+	SecurityProfile      SecurityProfile             `json:"security_profile,omitempty"`
+	LivenessProbe        *probe.Probe                `json:"liveness_probe,omitempty"`
+	ReadinessProbe       *probe.Probe                `json:"readiness_probe,omitempty"`
+	Expose               []port.Port                 `json:"expose,omitempty"`
+	Stdin                bool                        `json:"stdin,omitempty"`
+	StdinOnce            bool                        `json:"stdin_once,omitempty"`
+	TTY                  bool                        `json:"tty,omitempty"`
+	WorkingDir           string                      `json:"wd,omitempty"`
+	TerminationMsgPath   string                      `json:"termination_msg_path,omitempty"`
+	TerminationMsgPolicy TerminationMessagePolicy    `json:"termination_msg_policy,omitempty"`
+	ContainerID          string                      `json:"container_id,omitempty"`
+	ImageID              string                      `json:"image_id,omitempty"`
+	Ready                bool                        `json:"ready,omitempty"`
+	LastState            *ContainerState             `json:"last_state,omitempty"`
+	CurrentState         *ContainerState             `json:"current_state,omitempty"`
+	VolumeMounts         []volumemount.VolumeMount   `json:"volume,omitempty"`
+	Restarts             int32                       `json:"restarts,omitempty"`
+
+	// Extra holds the fields of the kubernetes container that mantle
+	// doesn't model, including those of its ports, env vars and mounts.
+	// It's merged into the container mantle generates.
+	Extra extra.Extra `json:"extra,omitempty"`
 }
 
 type ContainerState struct {
@@ -84,11 +95,13 @@ const (
 	TerminationMessageDefault
 )
 
+// PullPolicy is when the image is pulled. PullDefault derives it from
+// the image's tag, the way kubernetes does; see DefaultPullPolicy.
 type PullPolicy int
 
 const (
-	PullAlways PullPolicy = iota
+	PullDefault PullPolicy = iota
+	PullAlways
 	PullNever
 	PullIfNotPresent
-	PullDefault
 )
@@ -0,0 +1,39 @@
+package container
+
+import (
+	"strings"
+
+	"k8s.io/api/core/v1"
+)
+
+// DefaultTerminationMessagePath is the file kubernetes reads the
+// termination message from if the container doesn't set one
+const DefaultTerminationMessagePath = "/dev/termination-log"
+
+// DefaultPullPolicy is the pull policy kubernetes fills in for the image:
+// always for the latest tag or no tag, if-not-present otherwise. An image
+// with a digest and no tag is never pulled again.
+func DefaultPullPolicy(image string) v1.PullPolicy {
+	tag, digest := SplitImage(image)
+	if tag == "latest" || len(tag) == 0 && len(digest) == 0 {
+		return v1.PullAlways
+	}
+	return v1.PullIfNotPresent
+}
+
+// SplitImage returns the tag and digest of an image reference,
+// e.g. "latest" and "" for apachepulsar/pulsar:latest
+func SplitImage(image string) (string, string) {
+	var tag, digest string
+	if i := strings.Index(image, "@"); i >= 0 {
+		image, digest = image[:i], image[i+1:]
+	}
+
+	// A ':' before the last '/' separates a registry's host and port.
+	name := image[strings.LastIndex(image, "/")+1:]
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		tag = name[i+1:]
+	}
+
+	return tag, digest
+}
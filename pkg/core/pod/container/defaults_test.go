@@ -0,0 +1,41 @@
+package container
+
+import (
+	"testing"
+)
+
+func TestDefaultPullPolicy(t *testing.T) {
+	testCases := map[string]string{
+		"apachepulsar/pulsar":                          "Always",
+		"apachepulsar/pulsar:latest":                   "Always",
+		"apachepulsar/pulsar:2.2.1":                    "IfNotPresent",
+		"registry.local:5000/pulsar":                   "Always",
+		"registry.local:5000/pulsar:2.2.1":             "IfNotPresent",
+		"apachepulsar/pulsar@sha256:0123456789":        "IfNotPresent",
+		"apachepulsar/pulsar:latest@sha256:0123456789": "Always",
+		"apachepulsar/pulsar:2.2.1@sha256:0123456789":  "IfNotPresent",
+	}
+
+	for image, expected := range testCases {
+		if result := string(DefaultPullPolicy(image)); result != expected {
+			t.Errorf("%s: expected %s, got %s", image, expected, result)
+		}
+	}
+}
+
+func TestSplitImage(t *testing.T) {
+	tests := map[string][2]string{
+		"pulsar":                          {"", ""},
+		"apachepulsar/pulsar:2.1":         {"2.1", ""},
+		"registry:5000/pulsar":            {"", ""},
+		"registry:5000/pulsar:latest":     {"latest", ""},
+		"apachepulsar/pulsar@sha256:abcd": {"", "sha256:abcd"},
+	}
+
+	for image, expected := range tests {
+		tag, digest := SplitImage(image)
+		if tag != expected[0] || digest != expected[1] {
+			t.Errorf("%s: expected %v, got [%s %s]", image, expected, tag, digest)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package container
+
+import (
+	"mantle/pkg/util/enum"
+)
+
+//go:generate go run mantle/internal/enumgen
+
+// The names that enums are written as. See enum.Names.
+// The methods that read and write them are generated from them.
+var (
+	terminationMessagePolicyNames = enum.Names{"file", "fallback-to-logs-on-error", "default"}
+	pullPolicyNames               = enum.Names{"default", "always", "never", "if-not-present"}
+)
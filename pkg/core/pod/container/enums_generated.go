@@ -0,0 +1,63 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package container
+
+// EnumNames returns the names of the TerminationMessagePolicy values
+func (TerminationMessagePolicy) EnumNames() []string {
+	return terminationMessagePolicyNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t TerminationMessagePolicy) MarshalText() ([]byte, error) {
+	return terminationMessagePolicyNames.Text(int(t))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *TerminationMessagePolicy) UnmarshalText(text []byte) error {
+	value, err := terminationMessagePolicyNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*t = TerminationMessagePolicy(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (t *TerminationMessagePolicy) UnmarshalJSON(data []byte) error {
+	value, err := terminationMessagePolicyNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*t = TerminationMessagePolicy(value)
+	return nil
+}
+
+// EnumNames returns the names of the PullPolicy values
+func (PullPolicy) EnumNames() []string {
+	return pullPolicyNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (p PullPolicy) MarshalText() ([]byte, error) {
+	return pullPolicyNames.Text(int(p))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (p *PullPolicy) UnmarshalText(text []byte) error {
+	value, err := pullPolicyNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*p = PullPolicy(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (p *PullPolicy) UnmarshalJSON(data []byte) error {
+	value, err := pullPolicyNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*p = PullPolicy(value)
+	return nil
+}
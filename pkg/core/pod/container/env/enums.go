@@ -0,0 +1,13 @@
+package env
+
+import (
+	"mantle/pkg/util/enum"
+)
+
+//go:generate go run mantle/internal/enumgen
+
+// The names that enums are written as. See enum.Names.
+// The methods that read and write them are generated from them.
+var (
+	envTypeNames = enum.Names{"from", "val"}
+)
@@ -0,0 +1,33 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package env
+
+// EnumNames returns the names of the EnvType values
+func (EnvType) EnumNames() []string {
+	return envTypeNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (e EnvType) MarshalText() ([]byte, error) {
+	return envTypeNames.Text(int(e))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (e *EnvType) UnmarshalText(text []byte) error {
+	value, err := envTypeNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*e = EnvType(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (e *EnvType) UnmarshalJSON(data []byte) error {
+	value, err := envTypeNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*e = EnvType(value)
+	return nil
+}
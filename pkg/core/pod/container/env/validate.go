@@ -0,0 +1,67 @@
+package env
+
+import (
+	"mantle/pkg/util/validation"
+
+	kubevalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the env entry.
+// The problems are reported for the entry as a whole.
+func (e *Env) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	switch e.Type {
+	case EnvValEnvType:
+		if e.Val == nil {
+			return field.ErrorList{field.Required(nil, "value must be set")}
+		}
+		if len(e.Val.Key) == 0 {
+			errs = append(errs, field.Required(nil, "name must be set"))
+		}
+		errs = append(errs, envVarName(e.Val.Key)...)
+
+	case EnvFromEnvType:
+		if e.From == nil {
+			return field.ErrorList{field.Required(nil, "source must be set")}
+		}
+		from := e.From
+
+		switch from.From {
+		case EnvFromTypeConfig, EnvFromTypeSecret:
+			if len(from.ConfigMapOrSecretName) == 0 {
+				errs = append(errs, field.Required(nil, string(from.From)+" name must be set"))
+			}
+			errs = append(errs, validation.DNS1123Subdomain(nil, from.ConfigMapOrSecretName)...)
+			if len(from.ConfigMapOrSecretKey) > 0 {
+				for _, msg := range kubevalidation.IsConfigMapKey(from.ConfigMapOrSecretKey) {
+					errs = append(errs, field.Invalid(nil, from.ConfigMapOrSecretKey, msg))
+				}
+				errs = append(errs, envVarName(from.VarNameOrPrefix)...)
+			}
+		default:
+			if len(from.VarNameOrPrefix) == 0 {
+				errs = append(errs, field.Required(nil, "name must be set"))
+			}
+			errs = append(errs, envVarName(from.VarNameOrPrefix)...)
+		}
+
+	default:
+		errs = append(errs, field.NotSupported(nil, e.Type, []string{"value", "from"}))
+	}
+
+	return errs
+}
+
+func envVarName(name string) field.ErrorList {
+	if len(name) == 0 {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for _, msg := range kubevalidation.IsEnvVarName(name) {
+		errs = append(errs, field.Invalid(nil, name, msg))
+	}
+	return errs
+}
@@ -15,8 +15,6 @@ import (
 	"mantle/pkg/util/floatstr"
 
 	"k8s.io/api/core/v1"
-
-	"github.com/imdario/mergo"
 )
 
 // NewContainerFromKubeContainer will create a new Container object with
@@ -62,6 +60,18 @@ func fromKubeContainerV1(container *v1.Container) (*Container, error) {
 	}
 	mantleContainer.Mem = mem
 
+	storage, err := resources.NewStorageFromKubeResourceRequirements(container.Resources)
+	if err != nil {
+		return nil, err
+	}
+	mantleContainer.Storage = storage
+
+	extended, err := resources.NewExtendedResourcesFromKubeResourceRequirements(container.Resources)
+	if err != nil {
+		return nil, err
+	}
+	mantleContainer.Extended = extended
+
 	if container.SecurityContext != nil {
 		mantleContainer.Privileged = container.SecurityContext.Privileged
 		mantleContainer.AllowEscalation = container.SecurityContext.AllowPrivilegeEscalation
@@ -118,11 +128,7 @@ func fromKubeContainerV1(container *v1.Container) (*Container, error) {
 		return nil, err
 	}
 
-	err = mergo.Merge(&envs, envFroms)
-	if err != nil {
-		return nil, err
-	}
-	mantleContainer.Env = envs
+	mantleContainer.Env = append(envs, envFroms...)
 
 	volumeMounts, err := fromKubeVolumeMountsV1(container.VolumeMounts)
 	if err != nil {
@@ -222,7 +228,8 @@ func fromKubeContainerPortsV1(ports []v1.ContainerPort) ([]port.Port, error) {
 }
 
 func fromKubeTerminationMessagePolicyV1(p v1.TerminationMessagePolicy) TerminationMessagePolicy {
-	if p == v1.TerminationMessageReadFile {
+	// Kubernetes reads the file unless told otherwise.
+	if p == v1.TerminationMessageReadFile || p == "" {
 		return TerminationMessageReadFile
 	}
 
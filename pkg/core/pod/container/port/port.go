@@ -5,8 +5,9 @@ import (
 )
 
 type Port struct {
-	Name          string
-	Protocol      protocol.Protocol
+	Name string
+	// Protocol is left out when it's TCP, the kubernetes default
+	Protocol      protocol.Protocol `json:",omitempty"`
 	IP            string
 	HostPort      string
 	ContainerPort string
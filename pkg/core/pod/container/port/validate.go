@@ -0,0 +1,33 @@
+package port
+
+import (
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the port. The problems are reported
+// for the port as a whole, with the part of the port they concern, e.g.
+// host port, at the start of their detail.
+func (p *Port) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	if len(p.ContainerPort) == 0 {
+		errs = append(errs, field.Required(nil, "container port must be set"))
+	}
+	errs = append(errs, describe("container port", validation.Port(nil, p.ContainerPort, false))...)
+	if p.HostPort != "0" {
+		errs = append(errs, describe("host port", validation.Port(nil, p.HostPort, false))...)
+	}
+	errs = append(errs, describe("ip", validation.IP(nil, p.IP))...)
+	errs = append(errs, describe("name", validation.Port(nil, p.Name, true))...)
+
+	return errs
+}
+
+func describe(part string, errs field.ErrorList) field.ErrorList {
+	for _, err := range errs {
+		err.Detail = part + ": " + err.Detail
+	}
+	return errs
+}
@@ -4,7 +4,17 @@ import (
 	"mantle/pkg/core/action"
 )
 
-// Probe defines the parameters for a probe
+// Probe defines the parameters for a probe.
+// Timing can be attached compactly to a net probe:
+//
+//	readiness_probe: http://:8080/admin/v2/brokers/health delay=30 interval=10 timeout=5
+//
+// or written in the long form alongside the action:
+//
+//	liveness_probe:
+//	  exec: [bin/pulsar-admin, brokers, healthcheck]
+//	  delay: 30
+//	  min_count_fail: 3
 type Probe struct {
 	action.Action   `json:",inline"`
 	Delay           int32 `json:"delay,omitempty"`
@@ -13,3 +23,12 @@ type Probe struct {
 	MinCountFailure int32 `json:"min_count_fail,omitempty"`
 	Timeout         int32 `json:"timeout,omitempty"`
 }
+
+// The timings kubernetes fills in for a probe that doesn't set them.
+// They're left out when converting from kubernetes.
+const (
+	DefaultInterval        = 10
+	DefaultTimeout         = 1
+	DefaultMinCountSuccess = 1
+	DefaultMinCountFailure = 3
+)
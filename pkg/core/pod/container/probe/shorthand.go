@@ -0,0 +1,124 @@
+package probe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"mantle/pkg/core/action"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+type probeFields struct {
+	action.Fields   `json:",inline"`
+	Delay           int32 `json:"delay,omitempty"`
+	Interval        int32 `json:"interval,omitempty"`
+	MinCountSuccess int32 `json:"min_count_success,omitempty"`
+	MinCountFailure int32 `json:"min_count_fail,omitempty"`
+	Timeout         int32 `json:"timeout,omitempty"`
+}
+
+// compactTimings returns the " delay=.. interval=.. timeout=.." suffix
+// of the compact form. It's empty if the probe only has an action.
+func (p *Probe) compactTimings() string {
+	var timings []string
+
+	if p.Delay != 0 {
+		timings = append(timings, fmt.Sprintf("delay=%d", p.Delay))
+	}
+	if p.Interval != 0 {
+		timings = append(timings, fmt.Sprintf("interval=%d", p.Interval))
+	}
+	if p.Timeout != 0 {
+		timings = append(timings, fmt.Sprintf("timeout=%d", p.Timeout))
+	}
+
+	if len(timings) == 0 {
+		return ""
+	}
+
+	return " " + strings.Join(timings, " ")
+}
+
+func (p *Probe) initFromCompactString(str string) error {
+	segments := strings.Fields(str)
+	if len(segments) == 0 {
+		return serrors.InvalidValueErrorf(str, "empty probe")
+	}
+
+	if err := p.Action.InitFromURL(segments[0]); err != nil {
+		return err
+	}
+
+	for _, segment := range segments[1:] {
+		fields := strings.SplitN(segment, "=", 2)
+		if len(fields) != 2 {
+			return serrors.InvalidValueErrorf(segment, "expected delay=, interval= or timeout=")
+		}
+
+		val, err := strconv.ParseInt(fields[1], 10, 32)
+		if err != nil {
+			return serrors.InvalidValueContextErrorf(err, segment, "expected an integer number of seconds")
+		}
+
+		switch fields[0] {
+		case "delay":
+			p.Delay = int32(val)
+		case "interval":
+			p.Interval = int32(val)
+		case "timeout":
+			p.Timeout = int32(val)
+		default:
+			return serrors.InvalidValueErrorf(segment, "expected delay=, interval= or timeout=")
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (p Probe) MarshalJSON() ([]byte, error) {
+	fields, err := p.Action.ToFields()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields.Net) > 0 && len(fields.Headers) == 0 && p.MinCountSuccess == 0 && p.MinCountFailure == 0 {
+		return json.Marshal(fields.Net + p.compactTimings())
+	}
+
+	return json.Marshal(probeFields{
+		Fields:          *fields,
+		Delay:           p.Delay,
+		Interval:        p.Interval,
+		MinCountSuccess: p.MinCountSuccess,
+		MinCountFailure: p.MinCountFailure,
+		Timeout:         p.Timeout,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (p *Probe) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*p = Probe{}
+		return p.initFromCompactString(str)
+	}
+
+	fields := probeFields{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return serrors.InvalidValueForTypeErrorf(string(data), p, "expected a URL or {exec|net, headers, delay, interval, timeout}")
+	}
+
+	*p = Probe{
+		Delay:           fields.Delay,
+		Interval:        fields.Interval,
+		MinCountSuccess: fields.MinCountSuccess,
+		MinCountFailure: fields.MinCountFailure,
+		Timeout:         fields.Timeout,
+	}
+
+	return p.Action.InitFromFields(&fields.Fields)
+}
@@ -0,0 +1,143 @@
+package probe
+
+import (
+	"reflect"
+	"testing"
+
+	"mantle/pkg/core/action"
+
+	"github.com/koki/json"
+)
+
+func TestProbeRoundTrip(t *testing.T) {
+	testcases := []struct {
+		description string
+		input       string
+		expected    Probe
+	}{
+		{
+			description: "url",
+			input:       `"tcp://:2181"`,
+			expected: Probe{
+				Action: action.Action{ActionType: action.ActionTypeTCP, Port: "2181"},
+			},
+		},
+		{
+			description: "url with timings",
+			input:       `"http://:8080/admin/v2/brokers/health delay=30 interval=10 timeout=5"`,
+			expected: Probe{
+				Action:   action.Action{ActionType: action.ActionTypeHTTP, Port: "8080", Path: "/admin/v2/brokers/health"},
+				Delay:    30,
+				Interval: 10,
+				Timeout:  5,
+			},
+		},
+		{
+			description: "url with some timings",
+			input:       `"http://:8080/metrics timeout=5"`,
+			expected: Probe{
+				Action:  action.Action{ActionType: action.ActionTypeHTTP, Port: "8080", Path: "/metrics"},
+				Timeout: 5,
+			},
+		},
+		{
+			description: "min counts",
+			input:       `{"net":"http://:8080/metrics","delay":30,"min_count_success":2,"min_count_fail":5}`,
+			expected: Probe{
+				Action:          action.Action{ActionType: action.ActionTypeHTTP, Port: "8080", Path: "/metrics"},
+				Delay:           30,
+				MinCountSuccess: 2,
+				MinCountFailure: 5,
+			},
+		},
+		{
+			description: "headers",
+			input:       `{"net":"https://:443/status","headers":["Authorization:Bearer a:b"],"interval":10}`,
+			expected: Probe{
+				Action:   action.Action{ActionType: action.ActionTypeHTTPS, Port: "443", Path: "/status", Headers: []string{"Authorization:Bearer a:b"}},
+				Interval: 10,
+			},
+		},
+		{
+			description: "exec",
+			input:       `{"exec":["bin/pulsar-admin","brokers","healthcheck"],"delay":30,"timeout":5}`,
+			expected: Probe{
+				Action:  action.Action{ActionType: action.ActionTypeCommand, Command: []string{"bin/pulsar-admin", "brokers", "healthcheck"}},
+				Delay:   30,
+				Timeout: 5,
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		p := Probe{}
+		if err := json.Unmarshal([]byte(tc.input), &p); err != nil {
+			t.Errorf("%s: unmarshal failed with %v", tc.description, err)
+			continue
+		}
+		if !reflect.DeepEqual(p, tc.expected) {
+			t.Errorf("%s: expected %#v got %#v", tc.description, tc.expected, p)
+		}
+
+		out, err := json.Marshal(p)
+		if err != nil {
+			t.Errorf("%s: marshal failed with %v", tc.description, err)
+			continue
+		}
+		if string(out) != tc.input {
+			t.Errorf("%s: expected %s got %s", tc.description, tc.input, out)
+		}
+	}
+}
+
+func TestProbeLongFormWithURL(t *testing.T) {
+	p := Probe{}
+	if err := json.Unmarshal([]byte(`{"net":"tcp://:2181","delay":5}`), &p); err != nil {
+		t.Fatalf("unmarshal failed with %v", err)
+	}
+
+	out, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("marshal failed with %v", err)
+	}
+	if expected := `"tcp://:2181 delay=5"`; string(out) != expected {
+		t.Errorf("expected %s got %s", expected, out)
+	}
+}
+
+func TestProbeInvalid(t *testing.T) {
+	for _, input := range []string{
+		`""`,
+		`"   "`,
+		`"ftp://:21"`,
+		`"tcp://:2181 delay"`,
+		`"tcp://:2181 delay=soon"`,
+		`"tcp://:2181 delay=1.5"`,
+		`"tcp://:2181 retries=3"`,
+		`5`,
+	} {
+		p := Probe{}
+		if err := json.Unmarshal([]byte(input), &p); err == nil {
+			t.Errorf("%s: expected an error, got %#v", input, p)
+		}
+	}
+}
+
+func TestCompactTimings(t *testing.T) {
+	testcases := []struct {
+		probe    Probe
+		expected string
+	}{
+		{Probe{}, ""},
+		{Probe{MinCountFailure: 3}, ""},
+		{Probe{Delay: 30}, " delay=30"},
+		{Probe{Delay: 30, Interval: 10, Timeout: 5}, " delay=30 interval=10 timeout=5"},
+		{Probe{Interval: 10, Timeout: 5}, " interval=10 timeout=5"},
+	}
+
+	for _, tc := range testcases {
+		if timings := tc.probe.compactTimings(); timings != tc.expected {
+			t.Errorf("%#v: expected %q got %q", tc.probe, tc.expected, timings)
+		}
+	}
+}
@@ -29,9 +29,17 @@ func (p *Probe) toKubeV1() (*v1.Probe, error) {
 	return &v1.Probe{
 		Handler:             *h,
 		InitialDelaySeconds: p.Delay,
-		TimeoutSeconds:      p.Timeout,
-		PeriodSeconds:       p.Interval,
-		SuccessThreshold:    p.MinCountSuccess,
-		FailureThreshold:    p.MinCountFailure,
+		TimeoutSeconds:      withDefault(p.Timeout, DefaultTimeout),
+		PeriodSeconds:       withDefault(p.Interval, DefaultInterval),
+		SuccessThreshold:    withDefault(p.MinCountSuccess, DefaultMinCountSuccess),
+		FailureThreshold:    withDefault(p.MinCountFailure, DefaultMinCountFailure),
 	}, nil
 }
+
+// withDefault fills in the kubernetes default for an unset timing
+func withDefault(timing, def int32) int32 {
+	if timing == 0 {
+		return def
+	}
+	return timing
+}
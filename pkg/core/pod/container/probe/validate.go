@@ -0,0 +1,27 @@
+package probe
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the probe
+func (p *Probe) Validate() field.ErrorList {
+	errs := p.Action.Validate()
+
+	for _, timing := range []struct {
+		name  string
+		value int32
+	}{
+		{"delay", p.Delay},
+		{"interval", p.Interval},
+		{"min_count_success", p.MinCountSuccess},
+		{"min_count_fail", p.MinCountFailure},
+		{"timeout", p.Timeout},
+	} {
+		if timing.value < 0 {
+			errs = append(errs, field.Invalid(field.NewPath(timing.name), timing.value, "must not be negative"))
+		}
+	}
+
+	return errs
+}
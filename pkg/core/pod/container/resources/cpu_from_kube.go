@@ -23,24 +23,12 @@ func NewCPUFromKubeResourceRequirements(obj interface{}) (*CPU, error) {
 }
 
 func fromCPUResourceRequirementsV1(resources v1.ResourceRequirements) (*CPU, error) {
-	cpu := &CPU{}
-
-	if resources.Limits != nil {
-		max := ""
-		if q, ok := resources.Limits["cpu"]; ok {
-			max = q.String()
-		}
-		cpu.Max = max
-	}
-
-	if resources.Requests != nil {
-		min := ""
-		if q, ok := resources.Requests["cpu"]; ok {
-			min = q.String()
-		}
-		cpu.Min = min
+	min, max, err := rangeFromKubeResourceRequirementsV1(resources, v1.ResourceCPU, unitCores)
+	if err != nil {
+		return nil, err
 	}
 
+	cpu := &CPU{Min: min, Max: max}
 	if !cpu.IsEmpty() {
 		return cpu, nil
 	}
@@ -4,11 +4,7 @@ import (
 	"fmt"
 	"strings"
 
-	serrors "github.com/koki/structurederrors"
-
 	"k8s.io/api/core/v1"
-
-	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // ToKube will return a kubernetes ResourceRequirements object of the api version provided
@@ -27,25 +23,9 @@ func (c *CPU) toKubeV1() (*v1.ResourceRequirements, error) {
 	limits := v1.ResourceList{}
 	requests := v1.ResourceList{}
 
-	if c.Min != "" {
-		q, err := resource.ParseQuantity(c.Min)
-		if err != nil {
-			return nil, serrors.InvalidInstanceErrorf(c, "couldn't parse cpu min quantity: %s", err)
-		}
-		requests[v1.ResourceCPU] = q
-	}
-
-	if c.Max != "" {
-		q, err := resource.ParseQuantity(c.Max)
-		if err != nil {
-			return nil, serrors.InvalidInstanceErrorf(c, "couldn't parse cpu max quantity: %s", err)
-		}
-		limits[v1.ResourceCPU] = q
-	}
-
-	if len(requests) > 0 || len(requests) > 0 {
-		return &v1.ResourceRequirements{Limits: limits, Requests: requests}, nil
+	if err := rangeToKubeResourceListsV1(v1.ResourceCPU, c.Min, c.Max, requests, limits); err != nil {
+		return nil, err
 	}
 
-	return nil, nil
+	return newKubeResourceRequirementsV1(requests, limits), nil
 }
@@ -0,0 +1,62 @@
+package resources
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/api/core/v1"
+)
+
+// NewExtendedResourcesFromKubeResourceRequirements will create a new
+// ExtendedResources object with every resource from a provided kubernetes
+// ResourceRequirements object that isn't cpu, memory or ephemeral-storage
+func NewExtendedResourcesFromKubeResourceRequirements(obj interface{}) (ExtendedResources, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.ResourceRequirements{}):
+		return fromExtendedResourceRequirementsV1(obj.(v1.ResourceRequirements))
+	case reflect.TypeOf(&v1.ResourceRequirements{}):
+		o := obj.(*v1.ResourceRequirements)
+		return fromExtendedResourceRequirementsV1(*o)
+	default:
+		return nil, fmt.Errorf("unknown ResourceRequirements version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func isExtendedResourceV1(name v1.ResourceName) bool {
+	switch name {
+	case v1.ResourceCPU, v1.ResourceMemory, v1.ResourceEphemeralStorage:
+		return false
+	default:
+		return true
+	}
+}
+
+func fromExtendedResourceRequirementsV1(resources v1.ResourceRequirements) (ExtendedResources, error) {
+	var extended ExtendedResources
+
+	names := map[v1.ResourceName]bool{}
+	for name := range resources.Limits {
+		names[name] = true
+	}
+	for name := range resources.Requests {
+		names[name] = true
+	}
+
+	for name := range names {
+		if !isExtendedResourceV1(name) {
+			continue
+		}
+
+		min, max, err := rangeFromKubeResourceRequirementsV1(resources, name, unitCount)
+		if err != nil {
+			return nil, err
+		}
+
+		if extended == nil {
+			extended = ExtendedResources{}
+		}
+		extended[string(name)] = Extended{Min: min, Max: max}
+	}
+
+	return extended, nil
+}
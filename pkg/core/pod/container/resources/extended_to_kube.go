@@ -0,0 +1,40 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+)
+
+// ToKube will return a kubernetes ResourceRequirements object of the api version provided
+func (e ExtendedResources) ToKube(version string) (interface{}, error) {
+	switch strings.ToLower(version) {
+	case "v1":
+		return e.toKubeV1()
+	case "":
+		return e.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for ExtendedResources: %s", version)
+	}
+}
+
+func (e ExtendedResources) toKubeV1() (*v1.ResourceRequirements, error) {
+	limits := v1.ResourceList{}
+	requests := v1.ResourceList{}
+
+	for name, res := range e {
+		resourceName := v1.ResourceName(name)
+		if !isExtendedResourceV1(resourceName) {
+			return nil, serrors.InvalidInstanceErrorf(e, "%s is not an extended resource", name)
+		}
+
+		if err := rangeToKubeResourceListsV1(resourceName, res.Min, res.Max, requests, limits); err != nil {
+			return nil, err
+		}
+	}
+
+	return newKubeResourceRequirementsV1(requests, limits), nil
+}
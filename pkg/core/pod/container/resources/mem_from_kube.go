@@ -23,24 +23,12 @@ func NewMemFromKubeResourceRequirements(obj interface{}) (*Mem, error) {
 }
 
 func fromMemResourceRequirementsV1(resources v1.ResourceRequirements) (*Mem, error) {
-	mem := &Mem{}
-
-	if resources.Limits != nil {
-		max := ""
-		if q, ok := resources.Limits["memory"]; ok {
-			max = q.String()
-		}
-		mem.Max = max
-	}
-
-	if resources.Requests != nil {
-		min := ""
-		if q, ok := resources.Requests["memory"]; ok {
-			min = q.String()
-		}
-		mem.Min = min
+	min, max, err := rangeFromKubeResourceRequirementsV1(resources, v1.ResourceMemory, unitBytes)
+	if err != nil {
+		return nil, err
 	}
 
+	mem := &Mem{Min: min, Max: max}
 	if !mem.IsEmpty() {
 		return mem, nil
 	}
@@ -0,0 +1,250 @@
+package resources
+
+import (
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+/*
+A min/max pair can be written as a single string:
+
+  cpu: 500m-2     # requests 500m, limits 2
+  mem: 2Gi        # requests and limits 2Gi
+  mem: 1Gi-       # requests only
+  mem: -4Gi       # limits only
+
+or in its long form:
+
+  mem:
+    min: 1Gi
+    max: 4Gi
+
+Quantities are parsed when they are read and are always
+written back out in the canonical unit for their resource.
+*/
+
+type unit int
+
+const (
+	// unitCores is used for cpu. Quantities are written as cores or millicores.
+	unitCores unit = iota
+	// unitBytes is used for memory and storage. Quantities are written
+	// with the largest binary suffix that represents them exactly.
+	unitBytes
+	// unitCount is used for extended resources. Quantities are written as parsed.
+	unitCount
+)
+
+type rangeFields struct {
+	Min string `json:"min,omitempty"`
+	Max string `json:"max,omitempty"`
+}
+
+func normalizeQuantity(q resource.Quantity, u unit) resource.Quantity {
+	switch u {
+	case unitCores:
+		return *resource.NewMilliQuantity(q.MilliValue(), resource.DecimalSI)
+	case unitBytes:
+		if q.MilliValue()%1000 != 0 {
+			return q
+		}
+		if q.Value()%1024 == 0 {
+			return *resource.NewQuantity(q.Value(), resource.BinarySI)
+		}
+		return *resource.NewQuantity(q.Value(), resource.DecimalSI)
+	default:
+		return q
+	}
+}
+
+func normalizeQuantityPtr(q *resource.Quantity, u unit) *resource.Quantity {
+	if q == nil {
+		return nil
+	}
+
+	n := normalizeQuantity(*q, u)
+	return &n
+}
+
+func parseQuantity(str string, u unit) (*resource.Quantity, error) {
+	if len(str) == 0 {
+		return nil, nil
+	}
+
+	q, err := resource.ParseQuantity(str)
+	if err != nil {
+		return nil, serrors.InvalidValueContextErrorf(err, str, "couldn't parse quantity")
+	}
+	if q.Sign() < 0 {
+		return nil, serrors.InvalidValueErrorf(str, "quantity must not be negative")
+	}
+
+	n := normalizeQuantity(q, u)
+	return &n, nil
+}
+
+func quantityString(q *resource.Quantity) string {
+	if q == nil {
+		return ""
+	}
+
+	return q.String()
+}
+
+// checkRange rejects a range whose requests are larger than its limits.
+func checkRange(min, max *resource.Quantity) error {
+	if min != nil && max != nil && min.Cmp(*max) > 0 {
+		return serrors.InvalidValueErrorf(formatRange(min, max), "min (%s) is greater than max (%s)", min, max)
+	}
+
+	return nil
+}
+
+func formatRange(min, max *resource.Quantity) string {
+	switch {
+	case min == nil && max == nil:
+		return ""
+	case min != nil && max != nil && min.Cmp(*max) == 0:
+		return min.String()
+	case max == nil:
+		return quantityString(min) + "-"
+	case min == nil:
+		return "-" + max.String()
+	default:
+		return min.String() + "-" + max.String()
+	}
+}
+
+// splitRange finds the separator between min and max. A '-' that follows
+// an exponent marker belongs to the quantity (e.g. 1e-3), not the range.
+func splitRange(str string) (string, string, bool) {
+	for i := 0; i < len(str); i++ {
+		if str[i] != '-' {
+			continue
+		}
+		if i > 0 && (str[i-1] == 'e' || str[i-1] == 'E') && i < len(str)-1 {
+			continue
+		}
+		return str[:i], str[i+1:], true
+	}
+
+	return "", "", false
+}
+
+func parseRange(str string, u unit) (*resource.Quantity, *resource.Quantity, error) {
+	str = strings.TrimSpace(str)
+	if len(str) == 0 {
+		return nil, nil, nil
+	}
+
+	minStr, maxStr, ok := splitRange(str)
+	if !ok {
+		q, err := parseQuantity(str, u)
+		if err != nil {
+			return nil, nil, err
+		}
+		max := *q
+		return q, &max, nil
+	}
+
+	minStr = strings.TrimSpace(minStr)
+	maxStr = strings.TrimSpace(maxStr)
+	if len(minStr) == 0 && len(maxStr) == 0 {
+		return nil, nil, serrors.InvalidValueErrorf(str, "expected min-max, min- or -max")
+	}
+
+	return parseMinMax(minStr, maxStr, u)
+}
+
+func parseMinMax(minStr, maxStr string, u unit) (*resource.Quantity, *resource.Quantity, error) {
+	min, err := parseQuantity(minStr, u)
+	if err != nil {
+		return nil, nil, serrors.ContextualizeErrorf(err, "min")
+	}
+
+	max, err := parseQuantity(maxStr, u)
+	if err != nil {
+		return nil, nil, serrors.ContextualizeErrorf(err, "max")
+	}
+
+	if err := checkRange(min, max); err != nil {
+		return nil, nil, err
+	}
+
+	return min, max, nil
+}
+
+func marshalRange(min, max *resource.Quantity) ([]byte, error) {
+	return json.Marshal(formatRange(min, max))
+}
+
+func unmarshalRange(data []byte, u unit) (*resource.Quantity, *resource.Quantity, error) {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return parseRange(str, u)
+	}
+
+	var num json.Number
+	if err := json.Unmarshal(data, &num); err == nil {
+		return parseRange(num.String(), u)
+	}
+
+	fields := rangeFields{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, nil, serrors.InvalidValueForTypeErrorf(string(data), fields, "expected a string, a number or {min, max}")
+	}
+
+	return parseMinMax(fields.Min, fields.Max, u)
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (c CPU) MarshalJSON() ([]byte, error) {
+	return marshalRange(c.Min, c.Max)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (c *CPU) UnmarshalJSON(data []byte) error {
+	var err error
+	c.Min, c.Max, err = unmarshalRange(data, unitCores)
+	return err
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (m Mem) MarshalJSON() ([]byte, error) {
+	return marshalRange(m.Min, m.Max)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (m *Mem) UnmarshalJSON(data []byte) error {
+	var err error
+	m.Min, m.Max, err = unmarshalRange(data, unitBytes)
+	return err
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (s Storage) MarshalJSON() ([]byte, error) {
+	return marshalRange(s.Min, s.Max)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (s *Storage) UnmarshalJSON(data []byte) error {
+	var err error
+	s.Min, s.Max, err = unmarshalRange(data, unitBytes)
+	return err
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (e Extended) MarshalJSON() ([]byte, error) {
+	return marshalRange(e.Min, e.Max)
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (e *Extended) UnmarshalJSON(data []byte) error {
+	var err error
+	e.Min, e.Max, err = unmarshalRange(data, unitCount)
+	return err
+}
@@ -0,0 +1,28 @@
+package resources
+
+import (
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func rangeFromKubeResourceRequirementsV1(resources v1.ResourceRequirements, name v1.ResourceName, u unit) (*resource.Quantity, *resource.Quantity, error) {
+	var min *resource.Quantity
+	var max *resource.Quantity
+
+	if q, ok := resources.Requests[name]; ok {
+		min = normalizeQuantityPtr(&q, u)
+	}
+
+	if q, ok := resources.Limits[name]; ok {
+		max = normalizeQuantityPtr(&q, u)
+	}
+
+	if err := checkRange(min, max); err != nil {
+		return nil, nil, serrors.ContextualizeErrorf(err, "%s", name)
+	}
+
+	return min, max, nil
+}
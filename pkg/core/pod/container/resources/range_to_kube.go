@@ -0,0 +1,43 @@
+package resources
+
+import (
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func rangeToKubeResourceListsV1(name v1.ResourceName, min, max *resource.Quantity, requests, limits v1.ResourceList) error {
+	if err := checkRange(min, max); err != nil {
+		return serrors.ContextualizeErrorf(err, "%s", name)
+	}
+
+	if min != nil {
+		requests[name] = min.DeepCopy()
+	}
+
+	if max != nil {
+		limits[name] = max.DeepCopy()
+	}
+
+	return nil
+}
+
+func newKubeResourceRequirementsV1(requests, limits v1.ResourceList) *v1.ResourceRequirements {
+	requirements := &v1.ResourceRequirements{}
+
+	if len(requests) > 0 {
+		requirements.Requests = requests
+	}
+
+	if len(limits) > 0 {
+		requirements.Limits = limits
+	}
+
+	if requirements.Requests == nil && requirements.Limits == nil {
+		return nil
+	}
+
+	return requirements
+}
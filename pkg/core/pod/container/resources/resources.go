@@ -1,19 +1,51 @@
 package resources
 
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// CPU defines the cpu requests (Min) and limits (Max) of a container
 type CPU struct {
-	Min string `json:"min,omitempty"`
-	Max string `json:"max,omitempty"`
+	Min *resource.Quantity `json:"min,omitempty"`
+	Max *resource.Quantity `json:"max,omitempty"`
 }
 
 func (c *CPU) IsEmpty() bool {
-	return len(c.Min) == 0 && len(c.Max) == 0
+	return c.Min == nil && c.Max == nil
 }
 
+// Mem defines the memory requests (Min) and limits (Max) of a container
 type Mem struct {
-	Min string `json:"min,omitempty"`
-	Max string `json:"max,omitempty"`
+	Min *resource.Quantity `json:"min,omitempty"`
+	Max *resource.Quantity `json:"max,omitempty"`
 }
 
 func (m *Mem) IsEmpty() bool {
-	return len(m.Min) == 0 && len(m.Max) == 0
+	return m.Min == nil && m.Max == nil
+}
+
+// Storage defines the ephemeral storage requests (Min)
+// and limits (Max) of a container
+type Storage struct {
+	Min *resource.Quantity `json:"min,omitempty"`
+	Max *resource.Quantity `json:"max,omitempty"`
+}
+
+func (s *Storage) IsEmpty() bool {
+	return s.Min == nil && s.Max == nil
 }
+
+// Extended defines the requests (Min) and limits (Max)
+// of a single extended resource, e.g. example.com/foo
+type Extended struct {
+	Min *resource.Quantity `json:"min,omitempty"`
+	Max *resource.Quantity `json:"max,omitempty"`
+}
+
+func (e *Extended) IsEmpty() bool {
+	return e.Min == nil && e.Max == nil
+}
+
+// ExtendedResources maps a resource name to its requests and limits.
+// It holds every resource that isn't cpu, memory or ephemeral-storage.
+type ExtendedResources map[string]Extended
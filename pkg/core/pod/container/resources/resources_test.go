@@ -0,0 +1,253 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/koki/json"
+
+	"k8s.io/api/core/v1"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestUnmarshalRange(t *testing.T) {
+	testcases := []struct {
+		description string
+		input       string
+		min         string
+		max         string
+		pass        bool
+	}{
+		{
+			description: "min-max shorthand",
+			input:       `"500m-2"`,
+			min:         "500m",
+			max:         "2",
+			pass:        true,
+		},
+		{
+			description: "single value sets min and max",
+			input:       `"1.5"`,
+			min:         "1500m",
+			max:         "1500m",
+			pass:        true,
+		},
+		{
+			description: "number",
+			input:       `2`,
+			min:         "2",
+			max:         "2",
+			pass:        true,
+		},
+		{
+			description: "requests only",
+			input:       `"250m-"`,
+			min:         "250m",
+			pass:        true,
+		},
+		{
+			description: "limits only",
+			input:       `"-4"`,
+			max:         "4",
+			pass:        true,
+		},
+		{
+			description: "long form",
+			input:       `{"min": "0.1", "max": "1000m"}`,
+			min:         "100m",
+			max:         "1",
+			pass:        true,
+		},
+		{
+			description: "min greater than max",
+			input:       `"2-500m"`,
+			pass:        false,
+		},
+		{
+			description: "invalid quantity",
+			input:       `"lots"`,
+			pass:        false,
+		},
+		{
+			description: "missing min and max",
+			input:       `"-"`,
+			pass:        false,
+		},
+	}
+
+	for _, tc := range testcases {
+		cpu := CPU{}
+		err := json.Unmarshal([]byte(tc.input), &cpu)
+		if (err == nil) != tc.pass {
+			t.Errorf("%s: unexpected error result %v", tc.description, err)
+			continue
+		}
+		if !tc.pass {
+			continue
+		}
+
+		if got := quantityString(cpu.Min); got != tc.min {
+			t.Errorf("%s: wrong min, expected %q got %q", tc.description, tc.min, got)
+		}
+		if got := quantityString(cpu.Max); got != tc.max {
+			t.Errorf("%s: wrong max, expected %q got %q", tc.description, tc.max, got)
+		}
+	}
+}
+
+func TestMarshalRangeNormalizesUnits(t *testing.T) {
+	testcases := []struct {
+		description string
+		obj         interface{}
+		expected    string
+	}{
+		{
+			description: "memory in bytes",
+			obj:         Mem{Min: quantityPtr("1073741824"), Max: quantityPtr("4096Mi")},
+			expected:    `"1Gi-4Gi"`,
+		},
+		{
+			description: "memory with decimal suffix",
+			obj:         Mem{Max: quantityPtr("1G")},
+			expected:    `"-1G"`,
+		},
+		{
+			description: "fractional cpu",
+			obj:         CPU{Min: quantityPtr("0.5")},
+			expected:    `"500m-"`,
+		},
+		{
+			description: "equal min and max",
+			obj:         Storage{Min: quantityPtr("2Gi"), Max: quantityPtr("2048Mi")},
+			expected:    `"2Gi"`,
+		},
+		{
+			description: "no min or max",
+			obj:         Mem{},
+			expected:    `""`,
+		},
+	}
+
+	for _, tc := range testcases {
+		var input []byte
+		var err error
+		switch obj := tc.obj.(type) {
+		case Mem:
+			obj.Min, obj.Max = normalizeQuantityPtr(obj.Min, unitBytes), normalizeQuantityPtr(obj.Max, unitBytes)
+			input, err = json.Marshal(obj)
+		case CPU:
+			obj.Min, obj.Max = normalizeQuantityPtr(obj.Min, unitCores), normalizeQuantityPtr(obj.Max, unitCores)
+			input, err = json.Marshal(obj)
+		case Storage:
+			obj.Min, obj.Max = normalizeQuantityPtr(obj.Min, unitBytes), normalizeQuantityPtr(obj.Max, unitBytes)
+			input, err = json.Marshal(obj)
+		}
+		if err != nil {
+			t.Errorf("%s: marshal failed with %v", tc.description, err)
+			continue
+		}
+		if string(input) != tc.expected {
+			t.Errorf("%s: expected %s got %s", tc.description, tc.expected, input)
+		}
+
+		var str string
+		if err := json.Unmarshal(input, &str); err != nil {
+			t.Errorf("%s: %s isn't a string: %v", tc.description, input, err)
+			continue
+		}
+		if _, _, err := parseRange(str, unitBytes); err != nil {
+			t.Errorf("%s: %s can't be parsed back: %v", tc.description, input, err)
+		}
+	}
+}
+
+func TestCPUToKubeV1(t *testing.T) {
+	testcases := []struct {
+		description string
+		cpu         CPU
+		requests    bool
+		limits      bool
+	}{
+		{
+			description: "limits only",
+			cpu:         CPU{Max: quantityPtr("2")},
+			limits:      true,
+		},
+		{
+			description: "requests only",
+			cpu:         CPU{Min: quantityPtr("500m")},
+			requests:    true,
+		},
+		{
+			description: "requests and limits",
+			cpu:         CPU{Min: quantityPtr("500m"), Max: quantityPtr("2")},
+			requests:    true,
+			limits:      true,
+		},
+	}
+
+	for _, tc := range testcases {
+		kubeResources, err := tc.cpu.toKubeV1()
+		if err != nil {
+			t.Errorf("%s: ToKube failed with %v", tc.description, err)
+			continue
+		}
+		if kubeResources == nil {
+			t.Errorf("%s: ToKube dropped the cpu resources", tc.description)
+			continue
+		}
+
+		_, hasRequests := kubeResources.Requests[v1.ResourceCPU]
+		_, hasLimits := kubeResources.Limits[v1.ResourceCPU]
+		if hasRequests != tc.requests || hasLimits != tc.limits {
+			t.Errorf("%s: wrong requirements %v", tc.description, kubeResources)
+		}
+	}
+
+	if _, err := (&CPU{Min: quantityPtr("2"), Max: quantityPtr("1")}).toKubeV1(); err == nil {
+		t.Errorf("min greater than max was not rejected")
+	}
+}
+
+func TestExtendedResourcesRoundTrip(t *testing.T) {
+	kubeResources := v1.ResourceRequirements{
+		Limits: v1.ResourceList{
+			v1.ResourceCPU:    resource.MustParse("2"),
+			"example.com/foo": resource.MustParse("1"),
+			"hugepages-2Mi":   resource.MustParse("100Mi"),
+			v1.ResourceMemory: resource.MustParse("4Gi"),
+			"nvidia.com/gpu":  resource.MustParse("1"),
+		},
+		Requests: v1.ResourceList{
+			"example.com/foo": resource.MustParse("1"),
+		},
+	}
+
+	extended, err := NewExtendedResourcesFromKubeResourceRequirements(kubeResources)
+	if err != nil {
+		t.Fatalf("conversion failed with %v", err)
+	}
+	if len(extended) != 3 {
+		t.Fatalf("expected 3 extended resources, got %v", extended)
+	}
+
+	converted, err := extended.toKubeV1()
+	if err != nil {
+		t.Fatalf("ToKube failed with %v", err)
+	}
+	for _, name := range []v1.ResourceName{"example.com/foo", "hugepages-2Mi", "nvidia.com/gpu"} {
+		expected := kubeResources.Limits[name]
+		got := converted.Limits[name]
+		if expected.Cmp(got) != 0 {
+			t.Errorf("wrong %s limit, expected %s got %s", name, expected.String(), got.String())
+		}
+	}
+	if _, ok := converted.Limits[v1.ResourceCPU]; ok {
+		t.Errorf("cpu was treated as an extended resource")
+	}
+}
+
+func quantityPtr(str string) *resource.Quantity {
+	q := resource.MustParse(str)
+	return &q
+}
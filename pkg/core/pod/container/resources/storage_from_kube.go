@@ -0,0 +1,37 @@
+package resources
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/api/core/v1"
+)
+
+// NewStorageFromKubeResourceRequirements will create a new
+// Storage object with the data from a provided kubernetes
+// ResourceRequirements object
+func NewStorageFromKubeResourceRequirements(obj interface{}) (*Storage, error) {
+	switch reflect.TypeOf(obj) {
+	case reflect.TypeOf(v1.ResourceRequirements{}):
+		return fromStorageResourceRequirementsV1(obj.(v1.ResourceRequirements))
+	case reflect.TypeOf(&v1.ResourceRequirements{}):
+		o := obj.(*v1.ResourceRequirements)
+		return fromStorageResourceRequirementsV1(*o)
+	default:
+		return nil, fmt.Errorf("unknown ResourceRequirements version: %s", reflect.TypeOf(obj))
+	}
+}
+
+func fromStorageResourceRequirementsV1(resources v1.ResourceRequirements) (*Storage, error) {
+	min, max, err := rangeFromKubeResourceRequirementsV1(resources, v1.ResourceEphemeralStorage, unitBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	storage := &Storage{Min: min, Max: max}
+	if !storage.IsEmpty() {
+		return storage, nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,31 @@
+package resources
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/api/core/v1"
+)
+
+// ToKube will return a kubernetes ResourceRequirements object of the api version provided
+func (s *Storage) ToKube(version string) (interface{}, error) {
+	switch strings.ToLower(version) {
+	case "v1":
+		return s.toKubeV1()
+	case "":
+		return s.toKubeV1()
+	default:
+		return nil, fmt.Errorf("unsupported api version for Storage: %s", version)
+	}
+}
+
+func (s *Storage) toKubeV1() (*v1.ResourceRequirements, error) {
+	limits := v1.ResourceList{}
+	requests := v1.ResourceList{}
+
+	if err := rangeToKubeResourceListsV1(v1.ResourceEphemeralStorage, s.Min, s.Max, requests, limits); err != nil {
+		return nil, err
+	}
+
+	return newKubeResourceRequirementsV1(requests, limits), nil
+}
@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"sort"
+
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the cpu range
+func (c *CPU) Validate() field.ErrorList {
+	return validateRange(c.Min, c.Max)
+}
+
+// Validate returns every problem with the memory range
+func (m *Mem) Validate() field.ErrorList {
+	return validateRange(m.Min, m.Max)
+}
+
+// Validate returns every problem with the ephemeral storage range
+func (s *Storage) Validate() field.ErrorList {
+	return validateRange(s.Min, s.Max)
+}
+
+// Validate returns every problem with the extended resource range
+func (e *Extended) Validate() field.ErrorList {
+	return validateRange(e.Min, e.Max)
+}
+
+// Validate returns every problem with the extended resources,
+// with paths relative to the map
+func (e ExtendedResources) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		extended := e[name]
+		fldPath := validation.Key(name)
+		errs = append(errs, validation.QualifiedName(fldPath, name)...)
+		errs = append(errs, validation.Prefix(fldPath, extended.Validate())...)
+	}
+
+	return errs
+}
+
+func validateRange(min, max *resource.Quantity) field.ErrorList {
+	var errs field.ErrorList
+
+	if min != nil && min.Sign() < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("min"), min.String(), "must not be negative"))
+	}
+	if max != nil && max.Sign() < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("max"), max.String(), "must not be negative"))
+	}
+	if err := checkRange(min, max); err != nil {
+		errs = append(errs, field.Invalid(nil, formatRange(min, max), "min must not be greater than max"))
+	}
+
+	return errs
+}
@@ -0,0 +1,198 @@
+package container
+
+import (
+	"reflect"
+	"strings"
+
+	"mantle/pkg/util"
+
+	serrors "github.com/koki/structurederrors"
+)
+
+// SecurityProfile names a preset of security settings for a container.
+// It expands into the container's concrete security fields, and any
+// field set explicitly on the container overrides the preset.
+type SecurityProfile string
+
+const (
+	// SecurityProfilePrivileged doesn't restrict the container.
+	SecurityProfilePrivileged SecurityProfile = "privileged"
+
+	// SecurityProfileBaseline forbids privileged containers.
+	SecurityProfileBaseline SecurityProfile = "baseline"
+
+	// SecurityProfileRestricted runs the container as a non-root user
+	// without privilege escalation or capabilities, on a read-only root
+	// filesystem and with the runtime's default seccomp profile.
+	SecurityProfileRestricted SecurityProfile = "restricted"
+)
+
+// RestrictedUID is the user a restricted container runs as when
+// neither the container nor its pod sets one.
+const RestrictedUID int64 = 1000
+
+// Profile values understood by both seccomp and AppArmor.
+// Custom profiles are written as localhost/<profile>.
+const (
+	ProfileRuntimeDefault  = "runtime/default"
+	ProfileUnconfined      = "unconfined"
+	ProfileLocalhostPrefix = "localhost/"
+
+	// SeccompDockerDefault is the legacy name for the runtime's
+	// default seccomp profile.
+	SeccompDockerDefault = "docker/default"
+)
+
+const capabilityAll = "ALL"
+
+// PodSecurity holds the pod-wide settings that a security
+// profile defers to instead of setting them on the container.
+type PodSecurity struct {
+	UID          *int64
+	ForceNonRoot *bool
+	Seccomp      string
+}
+
+// WithSecurityProfile returns a copy of the container with its
+// security profile (or the given default, if it has none) expanded
+// into concrete fields.
+func (c Container) WithSecurityProfile(profile SecurityProfile, pod PodSecurity) (Container, error) {
+	if len(c.SecurityProfile) > 0 {
+		profile = c.SecurityProfile
+	}
+	c.SecurityProfile = ""
+
+	switch profile {
+	case "", SecurityProfilePrivileged:
+	case SecurityProfileBaseline:
+		if c.Privileged == nil {
+			c.Privileged = util.BoolPtr(false)
+		}
+	case SecurityProfileRestricted:
+		if c.ForceNonRoot == nil && pod.ForceNonRoot == nil {
+			c.ForceNonRoot = util.BoolPtr(true)
+		}
+		if c.AllowEscalation == nil {
+			c.AllowEscalation = util.BoolPtr(false)
+		}
+		if c.RO == nil && c.RW == nil {
+			c.RO = util.BoolPtr(true)
+		}
+		if c.DelCapabilities == nil {
+			c.DelCapabilities = []string{capabilityAll}
+		}
+		if c.UID == nil && pod.UID == nil {
+			uid := RestrictedUID
+			c.UID = &uid
+		}
+		if len(c.Seccomp) == 0 && len(pod.Seccomp) == 0 {
+			c.Seccomp = ProfileRuntimeDefault
+		}
+	default:
+		return Container{}, serrors.InvalidValueErrorf(profile, "expected %s, %s or %s",
+			SecurityProfilePrivileged, SecurityProfileBaseline, SecurityProfileRestricted)
+	}
+
+	return c, nil
+}
+
+// CollapseSecurityProfile returns a copy of the container with the
+// restricted profile in place of its security fields, if the container
+// meets that profile. Otherwise, the container is returned as is.
+func (c Container) CollapseSecurityProfile(pod PodSecurity) Container {
+	if len(c.SecurityProfile) > 0 || !c.isRestricted(pod) {
+		return c
+	}
+
+	collapsed := c
+	collapsed.SecurityProfile = SecurityProfileRestricted
+	if isBool(c.ForceNonRoot, true) && pod.ForceNonRoot == nil {
+		collapsed.ForceNonRoot = nil
+	}
+	if isBool(c.AllowEscalation, false) {
+		collapsed.AllowEscalation = nil
+	}
+	if isBool(c.RO, true) && c.RW == nil {
+		collapsed.RO = nil
+	}
+	if reflect.DeepEqual(c.DelCapabilities, []string{capabilityAll}) {
+		collapsed.DelCapabilities = nil
+	}
+	if c.UID != nil && *c.UID == RestrictedUID && pod.UID == nil {
+		collapsed.UID = nil
+	}
+	if c.Seccomp == ProfileRuntimeDefault && len(pod.Seccomp) == 0 {
+		collapsed.Seccomp = ""
+	}
+
+	// Only collapse if the preset reproduces the container exactly.
+	expanded, err := collapsed.WithSecurityProfile("", pod)
+	if err != nil || !reflect.DeepEqual(expanded, c) {
+		return c
+	}
+
+	return collapsed
+}
+
+func (c *Container) isRestricted(pod PodSecurity) bool {
+	forceNonRoot := c.ForceNonRoot
+	if forceNonRoot == nil {
+		forceNonRoot = pod.ForceNonRoot
+	}
+
+	uid := c.UID
+	if uid == nil {
+		uid = pod.UID
+	}
+
+	seccomp := c.Seccomp
+	if len(seccomp) == 0 {
+		seccomp = pod.Seccomp
+	}
+
+	dropsAll := false
+	for _, capability := range c.DelCapabilities {
+		if capability == capabilityAll {
+			dropsAll = true
+		}
+	}
+
+	return isBool(forceNonRoot, true) &&
+		isBool(c.AllowEscalation, false) &&
+		isBool(c.RO, true) &&
+		dropsAll &&
+		len(c.AddCapabilities) == 0 &&
+		!isBool(c.Privileged, true) &&
+		uid != nil && *uid != 0 &&
+		(seccomp == ProfileRuntimeDefault || seccomp == SeccompDockerDefault ||
+			strings.HasPrefix(seccomp, ProfileLocalhostPrefix))
+}
+
+func isBool(b *bool, value bool) bool {
+	return b != nil && *b == value
+}
+
+// CheckSeccompProfile rejects unknown seccomp profiles
+func CheckSeccompProfile(profile string) error {
+	if profile == SeccompDockerDefault {
+		return nil
+	}
+
+	return checkProfile(profile)
+}
+
+// CheckAppArmorProfile rejects unknown AppArmor profiles
+func CheckAppArmorProfile(profile string) error {
+	return checkProfile(profile)
+}
+
+func checkProfile(profile string) error {
+	switch {
+	case profile == ProfileRuntimeDefault, profile == ProfileUnconfined:
+		return nil
+	case strings.HasPrefix(profile, ProfileLocalhostPrefix) && len(profile) > len(ProfileLocalhostPrefix):
+		return nil
+	default:
+		return serrors.InvalidValueErrorf(profile, "expected %s, %s or %s<profile>", ProfileRuntimeDefault, ProfileUnconfined, ProfileLocalhostPrefix)
+	}
+}
@@ -0,0 +1,83 @@
+package container
+
+import (
+	"reflect"
+	"testing"
+
+	"mantle/pkg/util"
+)
+
+func TestRestrictedSecurityProfileExpansion(t *testing.T) {
+	c := Container{Name: "broker", SecurityProfile: SecurityProfileRestricted, UID: int64Ptr(10000)}
+
+	expanded, err := c.WithSecurityProfile("", PodSecurity{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expanded.SecurityProfile != "" {
+		t.Errorf("security profile wasn't cleared after expansion")
+	}
+	if !isBool(expanded.ForceNonRoot, true) || !isBool(expanded.AllowEscalation, false) || !isBool(expanded.RO, true) {
+		t.Errorf("restricted profile didn't set force_non_root, allow_escalation and ro: %+v", expanded)
+	}
+	if !reflect.DeepEqual(expanded.DelCapabilities, []string{"ALL"}) {
+		t.Errorf("restricted profile didn't drop all capabilities: %v", expanded.DelCapabilities)
+	}
+	if *expanded.UID != 10000 {
+		t.Errorf("explicit uid was overridden by the profile: %d", *expanded.UID)
+	}
+	if expanded.Seccomp != ProfileRuntimeDefault {
+		t.Errorf("restricted profile didn't set seccomp: %q", expanded.Seccomp)
+	}
+
+	collapsed := expanded.CollapseSecurityProfile(PodSecurity{})
+	if !reflect.DeepEqual(collapsed, c) {
+		t.Errorf("expected %+v after collapsing, got %+v", c, collapsed)
+	}
+}
+
+func TestRestrictedSecurityProfileDefersToPod(t *testing.T) {
+	pod := PodSecurity{UID: int64Ptr(2000), ForceNonRoot: util.BoolPtr(true), Seccomp: ProfileRuntimeDefault}
+	c := Container{Name: "broker", SecurityProfile: SecurityProfileRestricted}
+
+	expanded, err := c.WithSecurityProfile("", pod)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if expanded.UID != nil || expanded.ForceNonRoot != nil || expanded.Seccomp != "" {
+		t.Errorf("restricted profile overrode pod-wide settings: %+v", expanded)
+	}
+
+	if collapsed := expanded.CollapseSecurityProfile(pod); !reflect.DeepEqual(collapsed, c) {
+		t.Errorf("expected %+v after collapsing, got %+v", c, collapsed)
+	}
+}
+
+func TestSecurityProfileCollapseRequiresMatch(t *testing.T) {
+	c := Container{
+		Name:            "broker",
+		ForceNonRoot:    util.BoolPtr(true),
+		AllowEscalation: util.BoolPtr(true),
+		RO:              util.BoolPtr(true),
+		DelCapabilities: []string{"ALL"},
+		UID:             int64Ptr(RestrictedUID),
+		Seccomp:         ProfileRuntimeDefault,
+	}
+
+	if collapsed := c.CollapseSecurityProfile(PodSecurity{}); collapsed.SecurityProfile != "" {
+		t.Errorf("container allowing privilege escalation was collapsed to %s", collapsed.SecurityProfile)
+	}
+}
+
+func TestInvalidSecurityProfile(t *testing.T) {
+	c := Container{SecurityProfile: "hardened"}
+	if _, err := c.WithSecurityProfile("", PodSecurity{}); err == nil {
+		t.Errorf("expected an error for an unknown security profile")
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
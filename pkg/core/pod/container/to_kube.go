@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"mantle/pkg/util"
+	"mantle/pkg/util/objutil"
 
 	serrors "github.com/koki/structurederrors"
 
@@ -29,6 +30,14 @@ func (c *Container) ToKube(version string) (interface{}, error) {
 func (c *Container) toKubeV1() (v1.Container, error) {
 	kubeContainer := v1.Container{}
 
+	if len(c.SecurityProfile) > 0 {
+		expanded, err := c.WithSecurityProfile("", PodSecurity{})
+		if err != nil {
+			return v1.Container{}, serrors.ContextualizeErrorf(err, "container (%s) security profile", c.Name)
+		}
+		c = &expanded
+	}
+
 	kubeContainer.Name = c.Name
 	kubeContainer.Args = c.toKubeContainerArgsV1()
 	kubeContainer.Command = c.Command
@@ -59,7 +68,7 @@ func (c *Container) toKubeV1() (v1.Container, error) {
 	if c.LivenessProbe != nil {
 		livenessProbe, err := c.LivenessProbe.ToKube("v1")
 		if err != nil {
-			return v1.Container{}, err
+			return v1.Container{}, objutil.ErrorAtPath(err, "liveness_probe")
 		}
 		kubeContainer.LivenessProbe = livenessProbe.(*v1.Probe)
 	}
@@ -67,12 +76,15 @@ func (c *Container) toKubeV1() (v1.Container, error) {
 	if c.ReadinessProbe != nil {
 		readinessProbe, err := c.ReadinessProbe.ToKube("v1")
 		if err != nil {
-			return v1.Container{}, err
+			return v1.Container{}, objutil.ErrorAtPath(err, "readiness_probe")
 		}
 		kubeContainer.ReadinessProbe = readinessProbe.(*v1.Probe)
 	}
 
 	kubeContainer.TerminationMessagePath = c.TerminationMsgPath
+	if len(kubeContainer.TerminationMessagePath) == 0 {
+		kubeContainer.TerminationMessagePath = DefaultTerminationMessagePath
+	}
 	kubeContainer.TerminationMessagePolicy = c.toKubeTerminationMsgPolicyV1()
 	kubeContainer.ImagePullPolicy = c.toKubePullPolicyV1()
 	vm, err := c.toKubeVolumeMountV1()
@@ -97,6 +109,10 @@ func (c *Container) toKubeV1() (v1.Container, error) {
 	}
 	kubeContainer.SecurityContext = sc
 
+	if err := c.Extra.Apply(&kubeContainer); err != nil {
+		return v1.Container{}, objutil.ErrorAtPath(err, "extra")
+	}
+
 	return kubeContainer, nil
 }
 
@@ -115,10 +131,10 @@ func (c *Container) toKubeContainerArgsV1() []string {
 func (c *Container) toKubeContainerPortV1() ([]v1.ContainerPort, error) {
 	var kubeContainerPorts []v1.ContainerPort
 
-	for _, port := range c.Expose {
+	for i, port := range c.Expose {
 		kubePort, err := port.ToKube("v1")
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "expose", i)
 		}
 		port := kubePort.(*v1.ContainerPort)
 		kubeContainerPorts = append(kubeContainerPorts, *port)
@@ -130,10 +146,10 @@ func (c *Container) toKubeEnvVarV1() ([]v1.EnvVar, []v1.EnvFromSource, error) {
 	var envVars []v1.EnvVar
 	var envsFromSource []v1.EnvFromSource
 
-	for _, e := range c.Env {
+	for i, e := range c.Env {
 		envVar, envFromSrc, err := e.ToKube("v1")
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, objutil.ErrorAtPath(err, "env", i)
 		}
 
 		if !reflect.ValueOf(envVar).IsNil() {
@@ -142,7 +158,7 @@ func (c *Container) toKubeEnvVarV1() ([]v1.EnvVar, []v1.EnvFromSource, error) {
 		}
 
 		if !reflect.ValueOf(envFromSrc).IsNil() {
-			e := envVar.(*v1.EnvFromSource)
+			e := envFromSrc.(*v1.EnvFromSource)
 			envsFromSource = append(envsFromSource, *e)
 		}
 	}
@@ -150,6 +166,15 @@ func (c *Container) toKubeEnvVarV1() ([]v1.EnvVar, []v1.EnvFromSource, error) {
 	return envVars, envsFromSource, nil
 }
 
+type resourceConverter interface {
+	ToKube(version string) (interface{}, error)
+}
+
+type resourceField struct {
+	path      string
+	converter resourceConverter
+}
+
 func (c *Container) toKubeResourcesV1() (*v1.ResourceRequirements, error) {
 	limits := v1.ResourceList{}
 	requests := v1.ResourceList{}
@@ -158,33 +183,28 @@ func (c *Container) toKubeResourcesV1() (*v1.ResourceRequirements, error) {
 		Requests: requests,
 	}
 
+	var fields []resourceField
 	if c.CPU != nil {
-		cpuResources, err := c.CPU.ToKube("v1")
-		if err != nil {
-			return nil, err
-		}
-
-		if !reflect.ValueOf(cpuResources).IsNil() {
-			res := cpuResources.(*v1.ResourceRequirements)
-			err := mergo.Merge(&limits, res.Limits)
-			if err != nil {
-				return nil, err
-			}
-			err = mergo.Merge(&requests, res.Requests)
-			if err != nil {
-				return nil, err
-			}
-		}
+		fields = append(fields, resourceField{"cpu", c.CPU})
 	}
-
 	if c.Mem != nil {
-		memResources, err := c.Mem.ToKube("v1")
+		fields = append(fields, resourceField{"mem", c.Mem})
+	}
+	if c.Storage != nil {
+		fields = append(fields, resourceField{"ephemeral_storage", c.Storage})
+	}
+	if len(c.Extended) > 0 {
+		fields = append(fields, resourceField{"extended_resources", c.Extended})
+	}
+
+	for _, field := range fields {
+		kubeResources, err := field.converter.ToKube("v1")
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, field.path)
 		}
 
-		if !reflect.ValueOf(memResources).IsNil() {
-			res := memResources.(*v1.ResourceRequirements)
+		if !reflect.ValueOf(kubeResources).IsNil() {
+			res := kubeResources.(*v1.ResourceRequirements)
 			err := mergo.Merge(&limits, res.Limits)
 			if err != nil {
 				return nil, err
@@ -227,17 +247,17 @@ func (c *Container) toKubePullPolicyV1() v1.PullPolicy {
 		return v1.PullIfNotPresent
 
 	default:
-		return ""
+		return DefaultPullPolicy(c.Image)
 	}
 }
 
 func (c *Container) toKubeVolumeMountV1() ([]v1.VolumeMount, error) {
 	var kubeMounts []v1.VolumeMount
 
-	for _, mount := range c.VolumeMounts {
+	for i, mount := range c.VolumeMounts {
 		m, err := mount.ToKube("v1")
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "volume", i)
 		}
 		mountV1 := m.(*v1.VolumeMount)
 		kubeMounts = append(kubeMounts, *mountV1)
@@ -254,7 +274,7 @@ func (c *Container) toKubeLifecycleV1() (*v1.Lifecycle, error) {
 	if c.OnStart != nil {
 		kos, err := c.OnStart.ToKube("v1")
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "on_start")
 		}
 		kubeOnStart = kos.(*v1.Handler)
 	}
@@ -262,7 +282,7 @@ func (c *Container) toKubeLifecycleV1() (*v1.Lifecycle, error) {
 	if c.PreStop != nil {
 		kps, err := c.PreStop.ToKube("v1")
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "pre_stop")
 		}
 		kubePreStop = kps.(*v1.Handler)
 	}
@@ -341,7 +361,7 @@ func (c *Container) toKubeSecurityContextV1() (*v1.SecurityContext, error) {
 	if c.SELinux != nil {
 		sel, err := c.SELinux.ToKube("v1")
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "selinux")
 		}
 		sc.SELinuxOptions = sel.(*v1.SELinuxOptions)
 		mark = true
@@ -369,6 +389,10 @@ func (c *Container) ToKubeStatus(version string) (interface{}, error) {
 func (c *Container) toKubeStatusV1() (v1.ContainerStatus, error) {
 	var status v1.ContainerStatus
 
+	// The status's image is the one the container runs, which mantle
+	// doesn't keep apart from the image of the spec.
+	status.Name = c.Name
+	status.Image = c.Image
 	status.ContainerID = c.ContainerID
 	status.ImageID = c.ImageID
 	status.RestartCount = c.Restarts
@@ -0,0 +1,28 @@
+package container
+
+import (
+	"testing"
+
+	"mantle/pkg/core/pod/container/resources"
+	"mantle/pkg/util/objutil"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestResourceErrorPath(t *testing.T) {
+	min, max := resource.MustParse("2Gi"), resource.MustParse("1Gi")
+	c := Container{
+		Image:   "busybox",
+		Storage: &resources.Storage{Min: &min, Max: &max},
+	}
+
+	_, err := c.ToKube("v1")
+	if err == nil {
+		t.Fatalf("expected an error for min above max")
+	}
+
+	path, ok := objutil.ErrorPath(err)
+	if !ok || path != "ephemeral_storage" {
+		t.Errorf("expected the error at ephemeral_storage, got %q (%v)", path, err)
+	}
+}
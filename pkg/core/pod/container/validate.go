@@ -0,0 +1,102 @@
+package container
+
+import (
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the container
+func (c *Container) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validation.Required(field.NewPath("name"), c.Name)...)
+	errs = append(errs, validation.DNS1123Label(field.NewPath("name"), c.Name)...)
+	errs = append(errs, validation.Required(field.NewPath("image"), c.Image)...)
+
+	for i := range c.Env {
+		errs = append(errs, validation.Prefix(field.NewPath("env").Index(i), c.Env[i].Validate())...)
+	}
+
+	ports := map[string]bool{}
+	for i := range c.Expose {
+		fldPath := field.NewPath("expose").Index(i)
+		errs = append(errs, validation.Prefix(fldPath, c.Expose[i].Validate())...)
+
+		if name := c.Expose[i].Name; len(name) > 0 {
+			if ports[name] {
+				errs = append(errs, field.Duplicate(fldPath.Child("name"), name))
+			}
+			ports[name] = true
+		}
+	}
+
+	for i := range c.VolumeMounts {
+		errs = append(errs, validation.Prefix(field.NewPath("volume").Index(i), c.VolumeMounts[i].Validate())...)
+	}
+
+	if c.CPU != nil {
+		errs = append(errs, validation.Prefix(field.NewPath("cpu"), c.CPU.Validate())...)
+	}
+	if c.Mem != nil {
+		errs = append(errs, validation.Prefix(field.NewPath("mem"), c.Mem.Validate())...)
+	}
+	if c.Storage != nil {
+		errs = append(errs, validation.Prefix(field.NewPath("ephemeral_storage"), c.Storage.Validate())...)
+	}
+	errs = append(errs, validation.Prefix(field.NewPath("extended_resources"), c.Extended.Validate())...)
+
+	if c.OnStart != nil {
+		errs = append(errs, validation.Prefix(field.NewPath("on_start"), c.OnStart.Validate())...)
+	}
+	if c.PreStop != nil {
+		errs = append(errs, validation.Prefix(field.NewPath("pre_stop"), c.PreStop.Validate())...)
+	}
+	if c.LivenessProbe != nil {
+		errs = append(errs, validation.Prefix(field.NewPath("liveness_probe"), c.LivenessProbe.Validate())...)
+	}
+	if c.ReadinessProbe != nil {
+		errs = append(errs, validation.Prefix(field.NewPath("readiness_probe"), c.ReadinessProbe.Validate())...)
+	}
+
+	errs = append(errs, c.validateSecurity()...)
+
+	return errs
+}
+
+func (c *Container) validateSecurity() field.ErrorList {
+	var errs field.ErrorList
+
+	if c.RO != nil && c.RW != nil && *c.RO == *c.RW {
+		errs = append(errs, field.Invalid(field.NewPath("ro"), *c.RO, "conflicts with rw"))
+	}
+
+	if len(c.Seccomp) > 0 {
+		if err := CheckSeccompProfile(c.Seccomp); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("seccomp"), c.Seccomp, err.Error()))
+		}
+	}
+
+	if len(c.AppArmor) > 0 {
+		if err := CheckAppArmorProfile(c.AppArmor); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("apparmor"), c.AppArmor, err.Error()))
+		}
+	}
+
+	switch c.SecurityProfile {
+	case "", SecurityProfilePrivileged, SecurityProfileBaseline, SecurityProfileRestricted:
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("security_profile"), c.SecurityProfile, []string{
+			string(SecurityProfilePrivileged), string(SecurityProfileBaseline), string(SecurityProfileRestricted),
+		}))
+	}
+
+	if c.UID != nil && *c.UID < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("uid"), *c.UID, "must not be negative"))
+	}
+	if c.GID != nil && *c.GID < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("gid"), *c.GID, "must not be negative"))
+	}
+
+	return errs
+}
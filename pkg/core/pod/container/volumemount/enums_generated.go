@@ -0,0 +1,33 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package volumemount
+
+// EnumNames returns the names of the MountPropagation values
+func (MountPropagation) EnumNames() []string {
+	return mountPropagationNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (m MountPropagation) MarshalText() ([]byte, error) {
+	return mountPropagationNames.Text(int(m))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (m *MountPropagation) UnmarshalText(text []byte) error {
+	value, err := mountPropagationNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*m = MountPropagation(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (m *MountPropagation) UnmarshalJSON(data []byte) error {
+	value, err := mountPropagationNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*m = MountPropagation(value)
+	return nil
+}
@@ -0,0 +1,26 @@
+package volumemount
+
+import (
+	"strings"
+
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the volume mount
+func (m *VolumeMount) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validation.Required(field.NewPath("mount"), m.MountPath)...)
+	errs = append(errs, validation.Required(field.NewPath("store"), m.Store)...)
+	errs = append(errs, validation.DNS1123Label(field.NewPath("store"), m.VolumeName())...)
+
+	return errs
+}
+
+// VolumeName returns the name of the mounted volume. The store is
+// written as the volume name, optionally followed by :subpath
+func (m *VolumeMount) VolumeName() string {
+	return strings.SplitN(m.Store, ":", 2)[0]
+}
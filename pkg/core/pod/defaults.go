@@ -0,0 +1,104 @@
+package pod
+
+import (
+	"mantle/pkg/core/pod/container"
+	"mantle/pkg/core/pod/container/probe"
+	"mantle/pkg/core/pod/podtemplate"
+	"mantle/pkg/core/pod/volume/util"
+
+	"k8s.io/api/core/v1"
+)
+
+// StripDefaults drops the fields of the pod that are equal to the
+// defaults the API server fills in. They're left out when converting
+// from kubernetes, and filled in again when converting to kubernetes.
+func StripDefaults(pod *v1.Pod) {
+	stripPodSpecDefaults(&pod.Spec)
+}
+
+func stripPodSpecDefaults(spec *v1.PodSpec) {
+	if spec.RestartPolicy == v1.RestartPolicyAlways {
+		spec.RestartPolicy = ""
+	}
+	if spec.DNSPolicy == v1.DNSClusterFirst {
+		spec.DNSPolicy = ""
+	}
+	if spec.SchedulerName == podtemplate.DefaultSchedulerName {
+		spec.SchedulerName = ""
+	}
+	if spec.TerminationGracePeriodSeconds != nil && *spec.TerminationGracePeriodSeconds == podtemplate.DefaultTerminationGracePeriod {
+		spec.TerminationGracePeriodSeconds = nil
+	}
+	if spec.EnableServiceLinks != nil && *spec.EnableServiceLinks {
+		spec.EnableServiceLinks = nil
+	}
+
+	for i := range spec.Volumes {
+		stripVolumeSourceDefaults(&spec.Volumes[i].VolumeSource)
+	}
+	for i := range spec.InitContainers {
+		stripContainerDefaults(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		stripContainerDefaults(&spec.Containers[i])
+	}
+}
+
+func stripVolumeSourceDefaults(source *v1.VolumeSource) {
+	isDefaultMode := func(mode *int32) bool {
+		return mode != nil && *mode == int32(util.DefaultMode)
+	}
+
+	switch {
+	case source.ConfigMap != nil && isDefaultMode(source.ConfigMap.DefaultMode):
+		source.ConfigMap.DefaultMode = nil
+	case source.Secret != nil && isDefaultMode(source.Secret.DefaultMode):
+		source.Secret.DefaultMode = nil
+	case source.DownwardAPI != nil && isDefaultMode(source.DownwardAPI.DefaultMode):
+		source.DownwardAPI.DefaultMode = nil
+	case source.Projected != nil && isDefaultMode(source.Projected.DefaultMode):
+		source.Projected.DefaultMode = nil
+	}
+}
+
+func stripContainerDefaults(c *v1.Container) {
+	if c.TerminationMessagePath == container.DefaultTerminationMessagePath {
+		c.TerminationMessagePath = ""
+	}
+	if c.TerminationMessagePolicy == v1.TerminationMessageReadFile {
+		c.TerminationMessagePolicy = ""
+	}
+	if c.ImagePullPolicy == container.DefaultPullPolicy(c.Image) {
+		c.ImagePullPolicy = ""
+	}
+	for i := range c.Ports {
+		if c.Ports[i].Protocol == v1.ProtocolTCP {
+			c.Ports[i].Protocol = ""
+		}
+	}
+
+	stripProbeDefaults(c.LivenessProbe)
+	stripProbeDefaults(c.ReadinessProbe)
+}
+
+func stripProbeDefaults(p *v1.Probe) {
+	if p == nil {
+		return
+	}
+
+	if p.TimeoutSeconds == probe.DefaultTimeout {
+		p.TimeoutSeconds = 0
+	}
+	if p.PeriodSeconds == probe.DefaultInterval {
+		p.PeriodSeconds = 0
+	}
+	if p.SuccessThreshold == probe.DefaultMinCountSuccess {
+		p.SuccessThreshold = 0
+	}
+	if p.FailureThreshold == probe.DefaultMinCountFailure {
+		p.FailureThreshold = 0
+	}
+	if p.HTTPGet != nil && p.HTTPGet.Scheme == v1.URISchemeHTTP {
+		p.HTTPGet.Scheme = ""
+	}
+}
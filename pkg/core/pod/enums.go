@@ -0,0 +1,15 @@
+package pod
+
+import (
+	"mantle/pkg/util/enum"
+)
+
+//go:generate go run mantle/internal/enumgen
+
+// The names that enums are written as. See enum.Names.
+// The methods that read and write them are generated from them.
+var (
+	conditionStatusNames = enum.Names{"true", "false", "unknown", "invalid", "none"}
+	podPhaseNames        = enum.Names{"none", "pending", "running", "succeeded", "failed", "unknown"}
+	podQOSClassNames     = enum.Names{"none", "guaranteed", "burstable", "best-effort"}
+)
@@ -0,0 +1,93 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package pod
+
+// EnumNames returns the names of the ConditionStatus values
+func (ConditionStatus) EnumNames() []string {
+	return conditionStatusNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (c ConditionStatus) MarshalText() ([]byte, error) {
+	return conditionStatusNames.Text(int(c))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (c *ConditionStatus) UnmarshalText(text []byte) error {
+	value, err := conditionStatusNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*c = ConditionStatus(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (c *ConditionStatus) UnmarshalJSON(data []byte) error {
+	value, err := conditionStatusNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*c = ConditionStatus(value)
+	return nil
+}
+
+// EnumNames returns the names of the PodPhase values
+func (PodPhase) EnumNames() []string {
+	return podPhaseNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (p PodPhase) MarshalText() ([]byte, error) {
+	return podPhaseNames.Text(int(p))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (p *PodPhase) UnmarshalText(text []byte) error {
+	value, err := podPhaseNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*p = PodPhase(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (p *PodPhase) UnmarshalJSON(data []byte) error {
+	value, err := podPhaseNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*p = PodPhase(value)
+	return nil
+}
+
+// EnumNames returns the names of the PodQOSClass values
+func (PodQOSClass) EnumNames() []string {
+	return podQOSClassNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (p PodQOSClass) MarshalText() ([]byte, error) {
+	return podQOSClassNames.Text(int(p))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (p *PodQOSClass) UnmarshalText(text []byte) error {
+	value, err := podQOSClassNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*p = PodQOSClass(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (p *PodQOSClass) UnmarshalJSON(data []byte) error {
+	value, err := podQOSClassNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*p = PodQOSClass(value)
+	return nil
+}
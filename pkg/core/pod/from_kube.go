@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"reflect"
 
+	"mantle/pkg/core/meta"
 	. "mantle/pkg/core/pod/container"
 	. "mantle/pkg/core/pod/podtemplate"
+	"mantle/pkg/util/extra"
+	"mantle/pkg/util/objutil"
 
 	serrors "github.com/koki/structurederrors"
 
@@ -39,23 +42,29 @@ func fromKubePodV1(pod *v1.Pod) (*Pod, error) {
 
 	mantlePod.Version = pod.APIVersion
 
-	templateMeta, err := NewPodTemplateMetaFromKubeObjectMeta(pod.ObjectMeta)
+	objectMeta, err := meta.NewMetaFromKubeObjectMeta(pod.ObjectMeta)
 	if err != nil {
-		return nil, err
+		return nil, objutil.ErrorAtPath(err, "metadata")
 	}
-	mantlePod.PodTemplateMeta = *templateMeta
+	mantlePod.Meta = *objectMeta
 
-	template, err := NewPodTemplateFromKubePodSpec(pod.Spec)
+	// The defaults kubernetes fills in are left out.
+	spec := pod.Spec.DeepCopy()
+	stripPodSpecDefaults(spec)
+	template, err := NewPodTemplateFromKubePodSpec(*spec)
 	if err != nil {
-		return nil, err
+		return nil, objutil.ErrorAtPath(err, "spec")
 	}
 	mantlePod.PodTemplate = *template
 
+	fromKubeSecurityAnnotationsV1(mantlePod)
+	mantlePod.CollapseSecurityProfiles()
+
 	mantlePod.Msg = pod.Status.Message
 	mantlePod.Reason = pod.Status.Reason
 	phase, err := fromKubePodPhaseV1(pod.Status.Phase)
 	if err != nil {
-		return nil, err
+		return nil, objutil.ErrorAtPath(err, "status", "phase")
 	}
 	mantlePod.Phase = phase
 	mantlePod.IP = pod.Status.PodIP
@@ -64,21 +73,77 @@ func fromKubePodV1(pod *v1.Pod) (*Pod, error) {
 
 	qosClass, err := fromKubePodQOSClassV1(pod.Status.QOSClass)
 	if err != nil {
-		return nil, err
+		return nil, objutil.ErrorAtPath(err, "status", "qosClass")
 	}
 	mantlePod.QOS = qosClass
 
 	conditions, err := fromKubePodConditionV1(pod.Status.Conditions)
 	if err != nil {
-		return nil, err
+		return nil, objutil.ErrorAtPath(err, "status", "conditions")
 	}
 	mantlePod.Conditions = conditions
 
-	fromKubeContainerStatusV1(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses, mantlePod.Containers)
+	fromKubeContainerStatusV1(pod.Status.InitContainerStatuses, mantlePod.InitContainers)
+	fromKubeContainerStatusV1(pod.Status.ContainerStatuses, mantlePod.Containers)
+
+	if err := fromKubeExtraV1(pod, mantlePod); err != nil {
+		return nil, err
+	}
 
 	return mantlePod, nil
 }
 
+// fromKubeExtraV1 keeps the fields of the pod, and of its containers,
+// that the mantle pod doesn't model in their Extra fields. The status is
+// left out, since it's reported by kubernetes rather than applied.
+// Volumes of a type mantle doesn't support are kept whole. If the mantle
+// pod can't be converted back, e.g. because its requests are larger
+// than its limits, there's nothing to compare it with, and it keeps no
+// extra fields; the conversion reports the problem when it's encoded.
+func fromKubeExtraV1(pod *v1.Pod, mantlePod *Pod) error {
+	for name, v := range mantlePod.Volumes {
+		if !v.HasSource() {
+			delete(mantlePod.Volumes, name)
+		}
+	}
+
+	generated, err := mantlePod.toKubeV1()
+	if err != nil {
+		return nil
+	}
+
+	if err := fromKubeContainerExtraV1(pod.Spec.InitContainers, generated.Spec.InitContainers, mantlePod.InitContainers); err != nil {
+		return objutil.ErrorAtPath(err, "spec", "initContainers")
+	}
+	if err := fromKubeContainerExtraV1(pod.Spec.Containers, generated.Spec.Containers, mantlePod.Containers); err != nil {
+		return objutil.ErrorAtPath(err, "spec", "containers")
+	}
+
+	// The containers' fields are kept by the containers.
+	original := *pod
+	original.Spec.InitContainers = generated.Spec.InitContainers
+	original.Spec.Containers = generated.Spec.Containers
+
+	mantlePod.Extra, err = extra.Unmapped(&original, generated, "status")
+	return err
+}
+
+func fromKubeContainerExtraV1(kubeContainers, generated []v1.Container, containers []Container) error {
+	if len(kubeContainers) != len(generated) {
+		return fmt.Errorf("expected %d containers, got %d", len(kubeContainers), len(generated))
+	}
+
+	for i := range kubeContainers {
+		containerExtra, err := extra.Unmapped(kubeContainers[i], generated[i])
+		if err != nil {
+			return objutil.ErrorAtPath(err, i)
+		}
+		containers[i].Extra = containerExtra
+	}
+
+	return nil
+}
+
 func fromKubePodPhaseV1(phase v1.PodPhase) (PodPhase, error) {
 	switch phase {
 	case "":
@@ -135,11 +200,7 @@ func fromKubePodConditionV1(kubeConditions []v1.PodCondition) ([]PodCondition, e
 			LastTransitionTime: kubeCondition.LastTransitionTime,
 		}
 
-		typ, err := FromKubePodConditionTypeV1(kubeCondition.Type)
-		if err != nil {
-			return nil, err
-		}
-		condition.Type = typ
+		condition.Type = FromKubePodConditionTypeV1(kubeCondition.Type)
 
 		status, err := fromKubeConditionStatusV1(kubeCondition.Status)
 		if err != nil {
@@ -172,11 +233,12 @@ func fromKubeConditionStatusV1(status v1.ConditionStatus) (ConditionStatus, erro
 	}
 }
 
-func fromKubeContainerStatusV1(initContainerStatuses, containerStatuses []v1.ContainerStatus, containers []Container) {
-	allContainerStatuses := append(initContainerStatuses, containerStatuses...)
-
-	for _, status := range allContainerStatuses {
-		for _, container := range containers {
+// fromKubeContainerStatusV1 merges the statuses into the containers
+// they're about, matched by name
+func fromKubeContainerStatusV1(statuses []v1.ContainerStatus, containers []Container) {
+	for _, status := range statuses {
+		for i := range containers {
+			container := &containers[i]
 			if container.Name == status.Name {
 				container.Restarts = status.RestartCount
 				container.Ready = status.Ready
@@ -190,6 +252,10 @@ func fromKubeContainerStatusV1(initContainerStatuses, containerStatuses []v1.Con
 }
 
 func fromKubeContainerStateV1(state v1.ContainerState) *ContainerState {
+	if state.Waiting == nil && state.Running == nil && state.Terminated == nil {
+		return nil
+	}
+
 	s := &ContainerState{}
 
 	if state.Waiting != nil {
@@ -0,0 +1,21 @@
+package hostalias
+
+import (
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the host alias
+func (h *HostAlias) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validation.Required(field.NewPath("IP"), h.IP)...)
+	errs = append(errs, validation.IP(field.NewPath("IP"), h.IP)...)
+
+	for i, hostname := range h.Hostnames {
+		errs = append(errs, validation.DNS1123Subdomain(field.NewPath("Hostnames").Index(i), hostname)...)
+	}
+
+	return errs
+}
@@ -1,7 +1,9 @@
 package pod
 
 import (
+	"mantle/pkg/core/meta"
 	. "mantle/pkg/core/pod/podtemplate"
+	"mantle/pkg/util/extra"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -27,22 +29,25 @@ const (
 
 type PodPhase int
 
+// PodPhaseNone, the zero value, is a pod without a reported phase,
+// e.g. one that was never created
 const (
-	PodPhasePending PodPhase = iota
+	PodPhaseNone PodPhase = iota
+	PodPhasePending
 	PodPhaseRunning
 	PodPhaseSucceeded
 	PodPhaseFailed
 	PodPhaseUnknown
-	PodPhaseNone
 )
 
 type PodQOSClass int
 
+// PodQOSClassNone, the zero value, is a pod without a reported QOS class
 const (
-	PodQOSClassGuaranteed PodQOSClass = iota
+	PodQOSClassNone PodQOSClass = iota
+	PodQOSClassGuaranteed
 	PodQOSClassBurstable
 	PodQOSClassBestEffort
-	PodQOSClassNone
 )
 
 // Pod defines a pod object
@@ -58,6 +63,11 @@ type Pod struct {
 	QOS        PodQOSClass    `json:"qos,omitempty"`
 	Reason     string         `json:"reason,omitempty"`
 
-	PodTemplateMeta `json:",inline"`
-	PodTemplate     `json:",inline"`
+	meta.Meta   `json:",inline"`
+	PodTemplate `json:",inline"`
+
+	// Extra holds the fields of the kubernetes pod that mantle doesn't
+	// model, including those of its volumes, tolerations and other list
+	// items. It's merged into the pod mantle generates.
+	Extra extra.Extra `json:"extra,omitempty"`
 }
@@ -0,0 +1,328 @@
+package pod
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	. "mantle/pkg/core/pod/container"
+	. "mantle/pkg/core/pod/podtemplate"
+
+	"k8s.io/api/core/v1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestContainerStatus(t *testing.T) {
+	started := metav1.Date(2019, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	kubePod := &v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "broker"},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init", Image: "apachepulsar/pulsar:2.2.1"}},
+			Containers: []v1.Container{
+				{Name: "broker", Image: "apachepulsar/pulsar:2.2.1"},
+				{Name: "exporter", Image: "prom/exporter:1.0"},
+			},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			InitContainerStatuses: []v1.ContainerStatus{{
+				Name:        "init",
+				Image:       "apachepulsar/pulsar:2.2.1",
+				ImageID:     "docker-pullable://apachepulsar/pulsar@sha256:0123",
+				ContainerID: "docker://init",
+				State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+					ExitCode: 0, Reason: "Completed", StartedAt: started, FinishedAt: started,
+				}},
+			}},
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:         "broker",
+					Image:        "apachepulsar/pulsar:2.2.1",
+					ImageID:      "docker-pullable://apachepulsar/pulsar@sha256:0123",
+					ContainerID:  "docker://broker",
+					Ready:        true,
+					RestartCount: 2,
+					State:        v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: started}},
+					LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+						ExitCode: 137, Reason: "OOMKilled", StartedAt: started, FinishedAt: started,
+					}},
+				},
+				// Waiting containers don't have an ID yet.
+				{
+					Name:  "exporter",
+					Image: "prom/exporter:1.0",
+					State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+				},
+			},
+		},
+	}
+
+	mantlePod, err := NewPodFromKubePod(kubePod)
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+
+	broker := mantlePod.Containers[0]
+	if broker.Restarts != 2 || !broker.Ready || broker.ContainerID != "docker://broker" ||
+		broker.CurrentState == nil || broker.CurrentState.Running == nil ||
+		broker.LastState == nil || broker.LastState.Terminated == nil || broker.LastState.Terminated.ExitCode != 137 {
+		t.Errorf("expected the broker's status, got %+v", broker)
+	}
+	if init := mantlePod.InitContainers[0]; init.CurrentState == nil || init.CurrentState.Terminated == nil || init.LastState != nil {
+		t.Errorf("expected the init container's status, got %+v", init)
+	}
+
+	kubeObj, err := mantlePod.ToKube()
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+	status := kubeObj.(*v1.Pod).Status
+	if !reflect.DeepEqual(status.InitContainerStatuses, kubePod.Status.InitContainerStatuses) {
+		t.Errorf("expected %+v, got %+v", kubePod.Status.InitContainerStatuses, status.InitContainerStatuses)
+	}
+	if !reflect.DeepEqual(status.ContainerStatuses, kubePod.Status.ContainerStatuses) {
+		t.Errorf("expected %+v, got %+v", kubePod.Status.ContainerStatuses, status.ContainerStatuses)
+	}
+}
+
+func TestConditionTypes(t *testing.T) {
+	gate := v1.PodConditionType("target-health.elbv2.k8s.aws/pulsar-proxy")
+	kubePod := &v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "proxy"},
+		Spec: v1.PodSpec{
+			Containers:     []v1.Container{{Name: "proxy", Image: "apachepulsar/pulsar:2.2.1"}},
+			ReadinessGates: []v1.PodReadinessGate{{ConditionType: v1.ContainersReady}, {ConditionType: gate}},
+		},
+		Status: v1.PodStatus{
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodReady, Status: v1.ConditionTrue},
+				{Type: gate, Status: v1.ConditionFalse},
+			},
+		},
+	}
+
+	mantlePod, err := NewPodFromKubePod(kubePod)
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+
+	expectedGates := []PodConditionType{PodConditionContainersReady, PodConditionType(gate)}
+	if !reflect.DeepEqual(mantlePod.Gates, expectedGates) {
+		t.Errorf("expected gates %v, got %v", expectedGates, mantlePod.Gates)
+	}
+	if typ := mantlePod.Conditions[0].Type; typ != PodConditionReady {
+		t.Errorf("expected condition type %s, got %s", PodConditionReady, typ)
+	}
+
+	kubeObj, err := mantlePod.ToKube()
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+	converted := kubeObj.(*v1.Pod)
+	if !reflect.DeepEqual(converted.Spec.ReadinessGates, kubePod.Spec.ReadinessGates) {
+		t.Errorf("expected gates %v, got %v", kubePod.Spec.ReadinessGates, converted.Spec.ReadinessGates)
+	}
+	if !reflect.DeepEqual(converted.Status.Conditions, kubePod.Status.Conditions) {
+		t.Errorf("expected conditions %v, got %v", kubePod.Status.Conditions, converted.Status.Conditions)
+	}
+}
+
+func TestSecurityAnnotations(t *testing.T) {
+	kubePod := &v1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "broker",
+			Annotations: map[string]string{
+				"seccomp.security.alpha.kubernetes.io/pod":               "runtime/default",
+				"container.seccomp.security.alpha.kubernetes.io/broker":  "localhost/broker.json",
+				"container.apparmor.security.beta.kubernetes.io/init":    "runtime/default",
+				"container.apparmor.security.beta.kubernetes.io/broker":  "localhost/broker",
+				"container.apparmor.security.beta.kubernetes.io/unknown": "unconfined",
+				"prometheus.io/scrape":                                   "true",
+			},
+		},
+		Spec: v1.PodSpec{
+			InitContainers: []v1.Container{{Name: "init", Image: "apachepulsar/pulsar:2.2.1"}},
+			Containers:     []v1.Container{{Name: "broker", Image: "apachepulsar/pulsar:2.2.1"}},
+		},
+	}
+
+	mantlePod, err := NewPodFromKubePod(kubePod)
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+
+	if mantlePod.Seccomp != "runtime/default" {
+		t.Errorf("expected pod seccomp runtime/default, got %q", mantlePod.Seccomp)
+	}
+	if init := mantlePod.InitContainers[0]; init.AppArmor != "runtime/default" || len(init.Seccomp) > 0 {
+		t.Errorf("expected the init container's profiles, got %+v", init)
+	}
+	if broker := mantlePod.Containers[0]; broker.Seccomp != "localhost/broker.json" || broker.AppArmor != "localhost/broker" {
+		t.Errorf("expected the broker's profiles, got %+v", broker)
+	}
+	// Annotations for containers the pod doesn't have are kept as they are.
+	expectedAnnotations := map[string]string{
+		"container.apparmor.security.beta.kubernetes.io/unknown": "unconfined",
+		"prometheus.io/scrape": "true",
+	}
+	if !reflect.DeepEqual(mantlePod.Annotations, expectedAnnotations) {
+		t.Errorf("expected annotations %v, got %v", expectedAnnotations, mantlePod.Annotations)
+	}
+	if len(kubePod.Annotations) != 6 {
+		t.Errorf("expected the kubernetes pod's annotations to be left alone, got %v", kubePod.Annotations)
+	}
+
+	kubeObj, err := mantlePod.ToKube()
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+	if annotations := kubeObj.(*v1.Pod).Annotations; !reflect.DeepEqual(annotations, kubePod.Annotations) {
+		t.Errorf("expected annotations %v, got %v", kubePod.Annotations, annotations)
+	}
+}
+
+func TestPodAppArmor(t *testing.T) {
+	mantlePod := &Pod{}
+	mantlePod.Version = "v1"
+	mantlePod.Name = "broker"
+	mantlePod.AppArmor = "runtime/default"
+	mantlePod.Containers = []Container{
+		{Name: "broker", Image: "apachepulsar/pulsar:2.2.1"},
+		{Name: "exporter", Image: "prom/exporter:1.0", AppArmor: "unconfined"},
+	}
+
+	kubeObj, err := mantlePod.ToKube()
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+
+	// The pod's profile applies to the containers that don't set their own.
+	expected := map[string]string{
+		"container.apparmor.security.beta.kubernetes.io/broker":   "runtime/default",
+		"container.apparmor.security.beta.kubernetes.io/exporter": "unconfined",
+	}
+	if annotations := kubeObj.(*v1.Pod).Annotations; !reflect.DeepEqual(annotations, expected) {
+		t.Errorf("expected annotations %v, got %v", expected, annotations)
+	}
+}
+
+func TestUnknownSecurityProfile(t *testing.T) {
+	for _, mantlePod := range []*Pod{
+		{PodTemplate: PodTemplate{Seccomp: "docker/unknown"}},
+		{PodTemplate: PodTemplate{AppArmor: "localhost/"}},
+		{PodTemplate: PodTemplate{Containers: []Container{{Name: "broker", Seccomp: "unknown"}}}},
+		{PodTemplate: PodTemplate{Containers: []Container{{Name: "broker", AppArmor: "docker/default"}}}},
+	} {
+		mantlePod.Version = "v1"
+		if _, err := mantlePod.ToKube(); err == nil {
+			t.Errorf("expected an error for the unknown profile of %+v", mantlePod.PodTemplate)
+		}
+	}
+}
+
+func TestUnsupportedVolume(t *testing.T) {
+	kubePod := &v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "broker"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:  "broker",
+				Image: "apachepulsar/pulsar:2.2.1",
+				VolumeMounts: []v1.VolumeMount{
+					{Name: "data", MountPath: "/data"},
+					{Name: "secrets", MountPath: "/secrets"},
+				},
+			}},
+			Volumes: []v1.Volume{
+				{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+				// A csi volume: its source is dropped when the manifest is
+				// parsed, since this kubernetes API doesn't have it.
+				{Name: "secrets"},
+			},
+		},
+	}
+
+	mantlePod, err := NewPodFromKubePod(kubePod)
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+
+	if _, ok := mantlePod.Volumes["secrets"]; ok || len(mantlePod.Volumes) != 1 {
+		t.Errorf("expected only the data volume, got %+v", mantlePod.Volumes)
+	}
+	volumes, ok := mantlePod.Extra["spec"].(map[string]interface{})["volumes"].([]interface{})
+	if !ok || len(volumes) != 1 || volumes[0].(map[string]interface{})["name"] != "secrets" {
+		t.Errorf("expected the secrets volume in extra, got %v", mantlePod.Extra)
+	}
+
+	kubeObj, err := mantlePod.ToKube()
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+	if converted := kubeObj.(*v1.Pod).Spec.Volumes; !reflect.DeepEqual(converted, kubePod.Spec.Volumes) {
+		t.Errorf("expected volumes %+v, got %+v", kubePod.Spec.Volumes, converted)
+	}
+}
+
+func TestUnmodeledItemFields(t *testing.T) {
+	kubePod := &v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: "broker"},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:  "broker",
+				Image: "apachepulsar/pulsar:2.2.1",
+				// Mantle doesn't convert the host IP of a port back.
+				Ports: []v1.ContainerPort{
+					{ContainerPort: 8080, Protocol: v1.ProtocolTCP},
+					{Name: "pulsar", ContainerPort: 6650, HostPort: 6650, HostIP: "10.0.0.1", Protocol: v1.ProtocolTCP},
+				},
+			}},
+		},
+	}
+
+	mantlePod, err := NewPodFromKubePod(kubePod)
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+
+	kubeObj, err := mantlePod.ToKube()
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+	if converted := kubeObj.(*v1.Pod).Spec.Containers[0].Ports; !reflect.DeepEqual(converted, kubePod.Spec.Containers[0].Ports) {
+		t.Errorf("expected ports %+v, got %+v", kubePod.Spec.Containers[0].Ports, converted)
+	}
+}
+
+func TestUnconvertiblePod(t *testing.T) {
+	kubePod := &v1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "broker",
+			Annotations: map[string]string{seccompPodAnnotation: "docker/unknown"},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{Name: "broker", Image: "apachepulsar/pulsar:2.2.1"}},
+		},
+	}
+
+	// The pod can't be converted back, since mantle doesn't know its
+	// seccomp profile, but it can still be decoded.
+	mantlePod, err := NewPodFromKubePod(kubePod)
+	if err != nil {
+		t.Fatalf("unexpected error converting pod: %v", err)
+	}
+	if mantlePod.Seccomp != "docker/unknown" {
+		t.Errorf("expected the pod's seccomp profile, got %q", mantlePod.Seccomp)
+	}
+
+	if _, err := mantlePod.ToKube(); err == nil {
+		t.Errorf("expected an error converting the pod back")
+	}
+}
@@ -0,0 +1,15 @@
+package podtemplate
+
+import (
+	"mantle/pkg/util/enum"
+)
+
+//go:generate go run mantle/internal/enumgen
+
+// The names that enums are written as. See enum.Names.
+// The methods that read and write them are generated from them.
+var (
+	restartPolicyNames = enum.Names{"default", "always", "on-failure", "never"}
+	dNSPolicyNames     = enum.Names{"unset", "cluster-first-with-host-net", "cluster-first", "default", "none"}
+	hostModeNames      = enum.Names{"net", "pid", "ipc"}
+)
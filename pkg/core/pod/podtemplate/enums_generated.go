@@ -0,0 +1,93 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package podtemplate
+
+// EnumNames returns the names of the RestartPolicy values
+func (RestartPolicy) EnumNames() []string {
+	return restartPolicyNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (r RestartPolicy) MarshalText() ([]byte, error) {
+	return restartPolicyNames.Text(int(r))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (r *RestartPolicy) UnmarshalText(text []byte) error {
+	value, err := restartPolicyNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*r = RestartPolicy(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (r *RestartPolicy) UnmarshalJSON(data []byte) error {
+	value, err := restartPolicyNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*r = RestartPolicy(value)
+	return nil
+}
+
+// EnumNames returns the names of the DNSPolicy values
+func (DNSPolicy) EnumNames() []string {
+	return dNSPolicyNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d DNSPolicy) MarshalText() ([]byte, error) {
+	return dNSPolicyNames.Text(int(d))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *DNSPolicy) UnmarshalText(text []byte) error {
+	value, err := dNSPolicyNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*d = DNSPolicy(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (d *DNSPolicy) UnmarshalJSON(data []byte) error {
+	value, err := dNSPolicyNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*d = DNSPolicy(value)
+	return nil
+}
+
+// EnumNames returns the names of the HostMode values
+func (HostMode) EnumNames() []string {
+	return hostModeNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (h HostMode) MarshalText() ([]byte, error) {
+	return hostModeNames.Text(int(h))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (h *HostMode) UnmarshalText(text []byte) error {
+	value, err := hostModeNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*h = HostMode(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (h *HostMode) UnmarshalJSON(data []byte) error {
+	value, err := hostModeNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*h = HostMode(value)
+	return nil
+}
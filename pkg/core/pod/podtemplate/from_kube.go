@@ -10,6 +10,7 @@ import (
 	"mantle/pkg/core/pod/toleration"
 	"mantle/pkg/core/pod/volume"
 	"mantle/pkg/core/selinux"
+	"mantle/pkg/util/objutil"
 
 	"k8s.io/api/core/v1"
 
@@ -37,12 +38,12 @@ func fromKubePodSpecV1(kubeSpec v1.PodSpec) (*PodTemplate, error) {
 	mantlePod := &PodTemplate{}
 	mantlePod.Volumes, err = fromKubeVolumesV1(kubeSpec.Volumes)
 	if err != nil {
-		return nil, serrors.ContextualizeErrorf(err, "pod volumes")
+		return nil, objutil.ErrorAtPath(err, "volumes")
 	}
 
 	a, err := affinity.NewAffinityFromKubeAffinity(kubeSpec.Affinity)
 	if err != nil {
-		return nil, err
+		return nil, objutil.ErrorAtPath(err, "affinity")
 	}
 	if a != nil {
 		mantlePod.Affinity = a
@@ -53,10 +54,10 @@ func fromKubePodSpecV1(kubeSpec v1.PodSpec) (*PodTemplate, error) {
 	if kubeSpec.InitContainers != nil {
 		initContainers = make([]container.Container, 0)
 	}
-	for _, kubeContainer := range kubeSpec.InitContainers {
+	for i, kubeContainer := range kubeSpec.InitContainers {
 		c, err := container.NewContainerFromKubeContainer(&kubeContainer)
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "initContainers", i)
 		}
 		initContainers = append(initContainers, *c)
 	}
@@ -67,10 +68,10 @@ func fromKubePodSpecV1(kubeSpec v1.PodSpec) (*PodTemplate, error) {
 	if kubeSpec.Containers != nil {
 		containers = make([]container.Container, 0)
 	}
-	for _, kubeContainer := range kubeSpec.Containers {
+	for i, kubeContainer := range kubeSpec.Containers {
 		c, err := container.NewContainerFromKubeContainer(&kubeContainer)
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "containers", i)
 		}
 		containers = append(containers, *c)
 	}
@@ -78,13 +79,13 @@ func fromKubePodSpecV1(kubeSpec v1.PodSpec) (*PodTemplate, error) {
 
 	dnsPolicy, err := fromKubeDNSPolicyV1(kubeSpec.DNSPolicy)
 	if err != nil {
-		return nil, err
+		return nil, objutil.ErrorAtPath(err, "dnsPolicy")
 	}
 	mantlePod.DNSPolicy = dnsPolicy
 
 	aliases, err := fromKubeHostAliasesV1(kubeSpec.HostAliases)
 	if err != nil {
-		return nil, err
+		return nil, objutil.ErrorAtPath(err, "hostAliases")
 	}
 	mantlePod.HostAliases = aliases
 
@@ -95,7 +96,7 @@ func fromKubePodSpecV1(kubeSpec v1.PodSpec) (*PodTemplate, error) {
 
 	restartPolicy, err := fromKubeRestartPolicyV1(kubeSpec.RestartPolicy)
 	if err != nil {
-		return nil, err
+		return nil, objutil.ErrorAtPath(err, "restartPolicy")
 	}
 	mantlePod.RestartPolicy = restartPolicy
 
@@ -106,7 +107,7 @@ func fromKubePodSpecV1(kubeSpec v1.PodSpec) (*PodTemplate, error) {
 
 	tolerations, err := fromKubeTolerationsV1(kubeSpec.Tolerations)
 	if err != nil {
-		return nil, err
+		return nil, objutil.ErrorAtPath(err, "tolerations")
 	}
 	mantlePod.Tolerations = tolerations
 
@@ -120,33 +121,20 @@ func fromKubePodSpecV1(kubeSpec v1.PodSpec) (*PodTemplate, error) {
 		securityContext := kubeSpec.SecurityContext
 		mantlePod.GIDs = securityContext.SupplementalGroups
 		mantlePod.FSGID = securityContext.FSGroup
-		for i := range mantlePod.Containers {
-			container := &mantlePod.Containers[i]
-			if container.SELinux == nil {
-				sel, err := selinux.NewSELinuxFromKubeSELinuxOptions(securityContext.SELinuxOptions)
-				if err != nil {
-					return nil, err
-				}
-				container.SELinux = sel
-			}
-			if container.UID == nil {
-				container.UID = securityContext.RunAsUser
-			}
-			if container.GID == nil {
-				container.GID = securityContext.RunAsGroup
-			}
-			if container.ForceNonRoot == nil {
-				container.ForceNonRoot = securityContext.RunAsNonRoot
-			}
+		mantlePod.UID = securityContext.RunAsUser
+		mantlePod.GID = securityContext.RunAsGroup
+		mantlePod.ForceNonRoot = securityContext.RunAsNonRoot
+
+		sel, err := selinux.NewSELinuxFromKubeSELinuxOptions(securityContext.SELinuxOptions)
+		if err != nil {
+			return nil, objutil.ErrorAtPath(err, "securityContext", "seLinuxOptions")
 		}
+		mantlePod.SELinux = sel
+		mantlePod.Sysctls = fromKubeSysctlsV1(securityContext.Sysctls)
 	}
 
 	mantlePod.Nameservers, mantlePod.SearchDomains, mantlePod.ResolverOptions = fromKubePodDNSConfigV1(kubeSpec.DNSConfig)
-	gates, err := fromKubePodReadinessGateV1(kubeSpec.ReadinessGates)
-	if err != nil {
-		return nil, err
-	}
-	mantlePod.Gates = gates
+	mantlePod.Gates = fromKubePodReadinessGateV1(kubeSpec.ReadinessGates)
 	mantlePod.RuntimeClass = kubeSpec.RuntimeClassName
 	mantlePod.ServiceLinks = kubeSpec.EnableServiceLinks
 
@@ -160,11 +148,11 @@ func fromKubeVolumesV1(kubeVolumes []v1.Volume) (map[string]volume.Volume, error
 		volumes = make(map[string]volume.Volume)
 	}
 
-	for _, kubeVolume := range kubeVolumes {
+	for i, kubeVolume := range kubeVolumes {
 		name := kubeVolume.Name
 		volume, err := volume.NewVolumeFromKubeVolume(kubeVolume)
 		if err != nil {
-			return nil, serrors.ContextualizeErrorf(err, "volume (%s)", name)
+			return nil, objutil.ErrorAtPath(serrors.ContextualizeErrorf(err, "volume (%s)", name), i)
 		}
 		volumes[name] = *volume
 	}
@@ -174,15 +162,12 @@ func fromKubeVolumesV1(kubeVolumes []v1.Volume) (map[string]volume.Volume, error
 
 func fromKubeDNSPolicyV1(dnsPolicy v1.DNSPolicy) (DNSPolicy, error) {
 	switch dnsPolicy {
-	case "":
+	case "", v1.DNSClusterFirst:
 		return DNSUnset, nil
 
 	case v1.DNSClusterFirstWithHostNet:
 		return DNSClusterFirstWithHostNet, nil
 
-	case v1.DNSClusterFirst:
-		return DNSClusterFirst, nil
-
 	case v1.DNSDefault:
 		return DNSDefault, nil
 
@@ -264,12 +249,9 @@ func fromKubeRegistriesV1(ref []v1.LocalObjectReference) []string {
 
 func fromKubeRestartPolicyV1(policy v1.RestartPolicy) (RestartPolicy, error) {
 	switch policy {
-	case "":
+	case "", v1.RestartPolicyAlways:
 		return RestartPolicyDefault, nil
 
-	case v1.RestartPolicyAlways:
-		return RestartPolicyAlways, nil
-
 	case v1.RestartPolicyOnFailure:
 		return RestartPolicyOnFailure, nil
 
@@ -298,6 +280,23 @@ func fromKubeTolerationsV1(tolerations []v1.Toleration) ([]toleration.Toleration
 	return tols, nil
 }
 
+func fromKubeSysctlsV1(kubeSysctls []v1.Sysctl) []Sysctl {
+	var sysctls []Sysctl
+
+	if kubeSysctls != nil {
+		sysctls = make([]Sysctl, 0)
+	}
+
+	for _, kubeSysctl := range kubeSysctls {
+		sysctls = append(sysctls, Sysctl{
+			Name:  kubeSysctl.Name,
+			Value: kubeSysctl.Value,
+		})
+	}
+
+	return sysctls
+}
+
 func fromKubePodDNSConfigV1(kubeDNS *v1.PodDNSConfig) ([]string, []string, []ResolverOptions) {
 	var options []ResolverOptions
 	var nameservers []string
@@ -328,7 +327,7 @@ func fromKubePodDNSConfigV1(kubeDNS *v1.PodDNSConfig) ([]string, []string, []Res
 	return nameservers, domains, options
 }
 
-func fromKubePodReadinessGateV1(kubeGates []v1.PodReadinessGate) ([]PodConditionType, error) {
+func fromKubePodReadinessGateV1(kubeGates []v1.PodReadinessGate) []PodConditionType {
 	var gates []PodConditionType
 
 	if kubeGates != nil {
@@ -336,34 +335,19 @@ func fromKubePodReadinessGateV1(kubeGates []v1.PodReadinessGate) ([]PodCondition
 	}
 
 	for _, kubeCondition := range kubeGates {
-		gate, err := FromKubePodConditionTypeV1(kubeCondition.ConditionType)
-		if err != nil {
-			return nil, err
-		}
-		gates = append(gates, gate)
+		gates = append(gates, FromKubePodConditionTypeV1(kubeCondition.ConditionType))
 	}
 
-	return gates, nil
+	return gates
 }
 
-func FromKubePodConditionTypeV1(condition v1.PodConditionType) (PodConditionType, error) {
-	switch condition {
-	case "":
-		return PodConditionNone, nil
-
-	case v1.PodScheduled:
-		return PodConditionScheduled, nil
-
-	case v1.PodReady:
-		return PodConditionReady, nil
-
-	case v1.PodInitialized:
-		return PodConditionInitialized, nil
-
-	case v1.PodReasonUnschedulable:
-		return PodConditionReasonUnschedulable, nil
-
-	default:
-		return PodConditionNone, serrors.InvalidInstanceError(condition)
+// FromKubePodConditionTypeV1 converts a kubernetes condition type to
+// its short name, or keeps it as it is if it has none
+func FromKubePodConditionTypeV1(condition v1.PodConditionType) PodConditionType {
+	for cond, kubeCond := range kubePodConditionTypes {
+		if kubeCond == condition {
+			return cond
+		}
 	}
+	return PodConditionType(condition)
 }
@@ -1,63 +1,126 @@
 package podtemplate
 
 import (
+	"fmt"
+	"sort"
+
 	"mantle/pkg/core/pod/affinity"
 	"mantle/pkg/core/pod/container"
 	"mantle/pkg/core/pod/hostalias"
 	"mantle/pkg/core/pod/toleration"
 	"mantle/pkg/core/pod/volume"
+	"mantle/pkg/core/selinux"
+
+	"k8s.io/api/core/v1"
 )
 
 // PodTemplate defines attributes for a pod
+//
+// Seccomp is the pod-wide seccomp profile. AppArmor is a default
+// profile for every container that doesn't set its own; it's never
+// populated when converting from kubernetes, since kubernetes has
+// no pod-wide AppArmor profile. Likewise, SecurityProfile is the
+// default for every container that doesn't set its own.
 type PodTemplate struct {
-	Volumes                map[string]volume.Volume `json:"volumes,omitempty"`
-	InitContainers         []container.Container    `json:"init_containers,omitempty"`
-	Containers             []container.Container    `json:"containers,omitempty"`
-	RestartPolicy          RestartPolicy            `json:"restart_policy,omitempty"`
-	TerminationGracePeriod *int64                   `json:"termination_grace_period,omitempty"`
-	ActiveDeadline         *int64                   `json:"active_deadline,omitempty"`
-	DNSPolicy              DNSPolicy                `json:"dns_policy,omitempty"`
-	NodeSelector           map[string]string        `json:"nodeSelector,omitempty"`
-	Account                string                   `json:"account,omitempty"`
-	AutomountAccountToken  *bool                    `json:"automountAccountToken,omitempty"`
-	Node                   string                   `json:"node,omitempty"`
-	HostMode               []HostMode               `json:"host_mode,omitempty"`
-	ShareNamespace         *bool                    `json:"shareNamespace,omitempty"`
-	FSGID                  *int64                   `json:"fs_gid,omitempty"`
-	GIDs                   []int64                  `json:"gids,omitempty"`
-	Registries             []string                 `json:"registry_secrets,omitempty"`
-	Hostname               string                   `json:"hostname,omitempty"`
-	Affinity               *affinity.Affinity       `json:"affinity,omitempty"`
-	SchedulerName          string                   `json:"scheduler_name,omitempty"`
-	Tolerations            []toleration.Toleration  `json:"tolerations,omitempty"`
-	HostAliases            []hostalias.HostAlias    `json:"host_aliases,omitempty"`
-	PriorityClass          string                   `json:"priorityClass,omitempty"`
-	Priority               *int32                   `json:"priority",omitempty"`
-	Nameservers            []string                 `json:"nameservers,omitempty"`
-	SearchDomains          []string                 `json:"searchDomains,omitempty"`
-	ResolverOptions        []ResolverOptions        `json:"resolverOptions,omitempty"`
-	Gates                  []PodConditionType       `json:"gates,omitempty"`
-	RuntimeClass           *string                  `json:"runtimeClass,omitempty"`
-	ServiceLinks           *bool                    `json:"serviceLinks,omitempty"`
+	Volumes                map[string]volume.Volume  `json:"volumes,omitempty"`
+	InitContainers         []container.Container     `json:"init_containers,omitempty"`
+	Containers             []container.Container     `json:"containers,omitempty"`
+	RestartPolicy          RestartPolicy             `json:"restart_policy,omitempty"`
+	TerminationGracePeriod *int64                    `json:"termination_grace_period,omitempty"`
+	ActiveDeadline         *int64                    `json:"active_deadline,omitempty"`
+	DNSPolicy              DNSPolicy                 `json:"dns_policy,omitempty"`
+	NodeSelector           map[string]string         `json:"nodeSelector,omitempty"`
+	Account                string                    `json:"account,omitempty"`
+	AutomountAccountToken  *bool                     `json:"automountAccountToken,omitempty"`
+	Node                   string                    `json:"node,omitempty"`
+	HostMode               []HostMode                `json:"host_mode,omitempty"`
+	ShareNamespace         *bool                     `json:"shareNamespace,omitempty"`
+	FSGID                  *int64                    `json:"fs_gid,omitempty"`
+	GIDs                   []int64                   `json:"gids,omitempty"`
+	SELinux                *selinux.SELinux          `json:"selinux,omitempty"`
+	UID                    *int64                    `json:"uid,omitempty"`
+	GID                    *int64                    `json:"gid,omitempty"`
+	ForceNonRoot           *bool                     `json:"force_non_root,omitempty"`
+	Sysctls                []Sysctl                  `json:"sysctls,omitempty"`
+	Seccomp                string                    `json:"seccomp,omitempty"`
+	AppArmor               string                    `json:"apparmor,omitempty"`
+	SecurityProfile        container.SecurityProfile `json:"security_profile,omitempty"`
+	Registries             []string                  `json:"registry_secrets,omitempty"`
+	Hostname               string                    `json:"hostname,omitempty"`
+	Affinity               *affinity.Affinity        `json:"affinity,omitempty"`
+	SchedulerName          string                    `json:"scheduler_name,omitempty"`
+	Tolerations            []toleration.Toleration   `json:"tolerations,omitempty"`
+	HostAliases            []hostalias.HostAlias     `json:"host_aliases,omitempty"`
+	PriorityClass          string                    `json:"priorityClass,omitempty"`
+	Priority               *int32                    `json:"priority,omitempty"`
+	Nameservers            []string                  `json:"nameservers,omitempty"`
+	SearchDomains          []string                  `json:"searchDomains,omitempty"`
+	ResolverOptions        []ResolverOptions         `json:"resolverOptions,omitempty"`
+	Gates                  []PodConditionType        `json:"gates,omitempty"`
+	RuntimeClass           *string                   `json:"runtimeClass,omitempty"`
+	ServiceLinks           *bool                     `json:"serviceLinks,omitempty"`
 }
 
-type PodConditionType int
+// EachContainer calls f for each init container and container, along
+// with the field path of the container, e.g. init_containers[0]
+func (pt *PodTemplate) EachContainer(f func(c *container.Container, path string)) {
+	for i := range pt.InitContainers {
+		f(&pt.InitContainers[i], fmt.Sprintf("init_containers[%d]", i))
+	}
+	for i := range pt.Containers {
+		f(&pt.Containers[i], fmt.Sprintf("containers[%d]", i))
+	}
+}
+
+// VolumeNames returns the names of the pod's volumes in a stable order
+func (pt *PodTemplate) VolumeNames() []string {
+	var names []string
+	for name := range pt.Volumes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PodConditionType is the type of a pod condition or readiness gate.
+// The conditions kubernetes reports are written by their short names,
+// e.g. ready. Other types, like the conditions of custom readiness
+// gates, are written as they are, e.g.
+// target-health.elbv2.k8s.aws/pulsar-proxy.
+type PodConditionType string
 
 const (
-	PodConditionScheduled PodConditionType = iota
-	PodConditionReady
-	PodConditionInitialized
-	PodConditionReasonUnschedulable
-	PodConditionContainersReady
-	PodConditionNone
+	PodConditionScheduled           PodConditionType = "scheduled"
+	PodConditionReady               PodConditionType = "ready"
+	PodConditionInitialized         PodConditionType = "initialized"
+	PodConditionReasonUnschedulable PodConditionType = "unschedulable"
+	PodConditionContainersReady     PodConditionType = "containers-ready"
+	PodConditionNone                PodConditionType = ""
 )
 
+// The kubernetes condition types of the short names
+var kubePodConditionTypes = map[PodConditionType]v1.PodConditionType{
+	PodConditionScheduled:           v1.PodScheduled,
+	PodConditionReady:               v1.PodReady,
+	PodConditionInitialized:         v1.PodInitialized,
+	PodConditionReasonUnschedulable: v1.PodReasonUnschedulable,
+	PodConditionContainersReady:     v1.ContainersReady,
+}
+
+// Sysctl defines a namespaced kernel parameter for the pod.
+// It's written as name=value, e.g. net.core.somaxconn=1024
+type Sysctl struct {
+	Name  string `json:"-"`
+	Value string `json:"-"`
+}
+
 type ResolverOptions struct {
 	Name  string  `json:"name,omitempty"`
 	Value *string `json:"value,omitempty"`
 }
 
-// RestartPolicy defines the pod restart policy
+// RestartPolicy defines the pod restart policy. RestartPolicyDefault
+// is kubernetes' default, always.
 type RestartPolicy int
 
 const (
@@ -67,15 +130,23 @@ const (
 	RestartPolicyNever
 )
 
-// DNSPolicy defines the pod dns policy
+// DNSPolicy defines the pod dns policy. DNSUnset is kubernetes'
+// default, cluster-first.
 type DNSPolicy int
 
 const (
-	DNSClusterFirstWithHostNet DNSPolicy = iota
+	DNSUnset DNSPolicy = iota
+	DNSClusterFirstWithHostNet
 	DNSClusterFirst
 	DNSDefault
 	DNSNone
-	DNSUnset
+)
+
+// The defaults kubernetes fills in for a pod. They're left out when
+// converting from kubernetes.
+const (
+	DefaultSchedulerName          = "default-scheduler"
+	DefaultTerminationGracePeriod = 30
 )
 
 // HostMode defines the pod host mode
@@ -0,0 +1,58 @@
+package podtemplate
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/api/core/v1"
+)
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestPodSecurityContextRoundTrip(t *testing.T) {
+	testCases := []*v1.PodSecurityContext{
+		{
+			SELinuxOptions:     &v1.SELinuxOptions{User: "system_u", Role: "system_r", Type: "svirt_lxc_net_t", Level: "s0:c123,c456"},
+			RunAsUser:          int64Ptr(10000),
+			RunAsGroup:         int64Ptr(10001),
+			RunAsNonRoot:       boolPtr(true),
+			SupplementalGroups: []int64{10002, 10003},
+			FSGroup:            int64Ptr(10004),
+			Sysctls: []v1.Sysctl{
+				{Name: "net.core.somaxconn", Value: "1024"},
+				{Name: "net.ipv4.ip_local_port_range", Value: "1024 65535"},
+			},
+		},
+		{RunAsNonRoot: boolPtr(false)},
+		{SELinuxOptions: &v1.SELinuxOptions{Type: "spc_t"}},
+		nil,
+	}
+
+	for i, securityContext := range testCases {
+		spec := v1.PodSpec{
+			Containers:      []v1.Container{{Name: "broker", Image: "apachepulsar/pulsar:2.2.1"}},
+			SecurityContext: securityContext,
+		}
+
+		pt, err := NewPodTemplateFromKubePodSpec(spec)
+		if err != nil {
+			t.Errorf("case %d: unexpected error: %v", i, err)
+			continue
+		}
+		kubeSpec, err := pt.ToKube("v1")
+		if err != nil {
+			t.Errorf("case %d: unexpected error: %v", i, err)
+			continue
+		}
+
+		if result := kubeSpec.(*v1.PodSpec).SecurityContext; !reflect.DeepEqual(result, securityContext) {
+			t.Errorf("case %d: expected %+v, got %+v", i, securityContext, result)
+		}
+	}
+}
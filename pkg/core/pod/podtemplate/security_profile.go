@@ -0,0 +1,81 @@
+package podtemplate
+
+import (
+	"mantle/pkg/core/pod/container"
+
+	serrors "github.com/koki/structurederrors"
+)
+
+// ExpandSecurityProfiles returns a copy of the PodTemplate with the
+// security profile of each container expanded into concrete fields.
+func (pt *PodTemplate) ExpandSecurityProfiles() (*PodTemplate, error) {
+	expanded := *pt
+	expanded.SecurityProfile = ""
+
+	var err error
+	expanded.InitContainers, err = pt.expandContainerSecurityProfiles(pt.InitContainers)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded.Containers, err = pt.expandContainerSecurityProfiles(pt.Containers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &expanded, nil
+}
+
+func (pt *PodTemplate) expandContainerSecurityProfiles(containers []container.Container) ([]container.Container, error) {
+	if containers == nil {
+		return nil, nil
+	}
+
+	expanded := make([]container.Container, len(containers))
+	for i, c := range containers {
+		e, err := c.WithSecurityProfile(pt.SecurityProfile, pt.podSecurity())
+		if err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "container (%s) security profile", c.Name)
+		}
+		expanded[i] = e
+	}
+
+	return expanded, nil
+}
+
+// CollapseSecurityProfiles replaces the security fields of each
+// container with a matching security profile. If every container
+// ends up with the same profile, it's set on the pod instead.
+func (pt *PodTemplate) CollapseSecurityProfiles() {
+	var profile container.SecurityProfile
+	lift := true
+
+	for _, containers := range [][]container.Container{pt.InitContainers, pt.Containers} {
+		for i := range containers {
+			containers[i] = containers[i].CollapseSecurityProfile(pt.podSecurity())
+			if len(profile) == 0 {
+				profile = containers[i].SecurityProfile
+			}
+			lift = lift && len(profile) > 0 && containers[i].SecurityProfile == profile
+		}
+	}
+
+	if !lift || len(profile) == 0 || len(pt.SecurityProfile) > 0 {
+		return
+	}
+
+	pt.SecurityProfile = profile
+	for _, containers := range [][]container.Container{pt.InitContainers, pt.Containers} {
+		for i := range containers {
+			containers[i].SecurityProfile = ""
+		}
+	}
+}
+
+func (pt *PodTemplate) podSecurity() container.PodSecurity {
+	return container.PodSecurity{
+		UID:          pt.UID,
+		ForceNonRoot: pt.ForceNonRoot,
+		Seccomp:      pt.Seccomp,
+	}
+}
@@ -0,0 +1,42 @@
+package podtemplate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+// String returns the name=value form of the sysctl
+func (s *Sysctl) String() string {
+	return fmt.Sprintf("%s=%s", s.Name, s.Value)
+}
+
+// InitFromString sets the sysctl from its name=value form
+func (s *Sysctl) InitFromString(str string) error {
+	fields := strings.SplitN(str, "=", 2)
+	if len(fields) != 2 || len(fields[0]) == 0 {
+		return serrors.InvalidValueErrorf(str, "expected name=value")
+	}
+
+	s.Name = fields[0]
+	s.Value = fields[1]
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (s Sysctl) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (s *Sysctl) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return serrors.InvalidValueForTypeErrorf(string(data), s, "expected name=value")
+	}
+
+	return s.InitFromString(str)
+}
@@ -0,0 +1,40 @@
+package podtemplate
+
+import (
+	"testing"
+
+	"github.com/koki/json"
+)
+
+func TestSysctl(t *testing.T) {
+	testCases := []struct {
+		str      string
+		expected Sysctl
+	}{
+		{"net.core.somaxconn=1024", Sysctl{Name: "net.core.somaxconn", Value: "1024"}},
+		{"net.ipv4.ip_local_port_range=1024 65535", Sysctl{Name: "net.ipv4.ip_local_port_range", Value: "1024 65535"}},
+		{"kernel.msgmax=", Sysctl{Name: "kernel.msgmax"}},
+		{"kernel.sem=a=b", Sysctl{Name: "kernel.sem", Value: "a=b"}},
+	}
+
+	for _, testCase := range testCases {
+		var s Sysctl
+		if err := json.Unmarshal([]byte(`"`+testCase.str+`"`), &s); err != nil {
+			t.Errorf("%s: unexpected error: %v", testCase.str, err)
+			continue
+		}
+		if s != testCase.expected {
+			t.Errorf("%s: expected %+v, got %+v", testCase.str, testCase.expected, s)
+		}
+		if result := s.String(); result != testCase.str {
+			t.Errorf("%s: expected it to be written back as it was, got %s", testCase.str, result)
+		}
+	}
+
+	for _, invalid := range []string{`"net.core.somaxconn"`, `"=1024"`, `{"name": "kernel.msgmax"}`} {
+		var s Sysctl
+		if err := json.Unmarshal([]byte(invalid), &s); err == nil {
+			t.Errorf("%s: expected an error", invalid)
+		}
+	}
+}
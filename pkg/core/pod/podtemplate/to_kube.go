@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	"mantle/pkg/util"
+	"mantle/pkg/util/objutil"
+
 	"k8s.io/api/core/v1"
 
 	serrors "github.com/koki/structurederrors"
@@ -23,7 +26,10 @@ func (pt *PodTemplate) ToKube(version string) (interface{}, error) {
 }
 
 func (pt *PodTemplate) toKubeV1(apiVersion string) (*v1.PodSpec, error) {
-	var err error
+	pt, err := pt.ExpandSecurityProfiles()
+	if err != nil {
+		return nil, err
+	}
 
 	spec := v1.PodSpec{}
 
@@ -41,10 +47,10 @@ func (pt *PodTemplate) toKubeV1(apiVersion string) (*v1.PodSpec, error) {
 	}
 
 	var initContainers []v1.Container
-	for _, container := range pt.InitContainers {
+	for i, container := range pt.InitContainers {
 		kc, err := container.ToKube("v1")
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "init_containers", i)
 		}
 		kubeContainer := kc.(v1.Container)
 		initContainers = append(initContainers, kubeContainer)
@@ -52,10 +58,10 @@ func (pt *PodTemplate) toKubeV1(apiVersion string) (*v1.PodSpec, error) {
 	spec.InitContainers = initContainers
 
 	var kubeContainers []v1.Container
-	for _, container := range pt.Containers {
+	for i, container := range pt.Containers {
 		kc, err := container.ToKube("v1")
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "containers", i)
 		}
 		kubeContainer := kc.(v1.Container)
 		kubeContainers = append(kubeContainers, kubeContainer)
@@ -76,11 +82,14 @@ func (pt *PodTemplate) toKubeV1(apiVersion string) (*v1.PodSpec, error) {
 
 	affinity, err := pt.Affinity.ToKube("v1")
 	if err != nil {
-		return nil, err
+		return nil, objutil.ErrorAtPath(err, "affinity")
 	}
 	spec.Affinity = affinity.(*v1.Affinity)
 
 	spec.TerminationGracePeriodSeconds = pt.TerminationGracePeriod
+	if spec.TerminationGracePeriodSeconds == nil {
+		spec.TerminationGracePeriodSeconds = util.Int64Ptr(DefaultTerminationGracePeriod)
+	}
 	spec.ActiveDeadlineSeconds = pt.ActiveDeadline
 
 	dnsPolicy, err := pt.toKubeDNSPolicyV1()
@@ -104,6 +113,9 @@ func (pt *PodTemplate) toKubeV1(apiVersion string) (*v1.PodSpec, error) {
 	spec.ShareProcessNamespace = pt.ShareNamespace
 	spec.ImagePullSecrets = pt.toKubeRegistriesV1()
 	spec.SchedulerName = pt.SchedulerName
+	if len(spec.SchedulerName) == 0 {
+		spec.SchedulerName = DefaultSchedulerName
+	}
 
 	tolerations, err := pt.toKubeTolerationsV1()
 	if err != nil {
@@ -111,11 +123,11 @@ func (pt *PodTemplate) toKubeV1(apiVersion string) (*v1.PodSpec, error) {
 	}
 	spec.Tolerations = tolerations
 
-	if pt.FSGID != nil || pt.GIDs != nil {
-		spec.SecurityContext = &v1.PodSecurityContext{}
-		spec.SecurityContext.FSGroup = pt.FSGID
-		spec.SecurityContext.SupplementalGroups = pt.GIDs
+	securityContext, err := pt.toKubePodSecurityContextV1()
+	if err != nil {
+		return nil, err
 	}
+	spec.SecurityContext = securityContext
 
 	spec.Priority = pt.Priority
 	spec.PriorityClassName = pt.PriorityClass
@@ -124,6 +136,9 @@ func (pt *PodTemplate) toKubeV1(apiVersion string) (*v1.PodSpec, error) {
 	spec.ReadinessGates = pt.toKubePodReadinessGatesV1()
 	spec.RuntimeClassName = pt.RuntimeClass
 	spec.EnableServiceLinks = pt.ServiceLinks
+	if spec.EnableServiceLinks == nil {
+		spec.EnableServiceLinks = util.BoolPtr(true)
+	}
 
 	return &spec, nil
 }
@@ -134,7 +149,7 @@ func (pt *PodTemplate) toKubeVolumesV1() ([]v1.Volume, error) {
 	for name, vol := range pt.Volumes {
 		v, err := vol.ToKube("v1")
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "volumes", name)
 		}
 		kubeVol := v.(*v1.Volume)
 		kubeVol.Name = name
@@ -144,13 +159,48 @@ func (pt *PodTemplate) toKubeVolumesV1() ([]v1.Volume, error) {
 	return kubeVolumes, nil
 }
 
+func (pt *PodTemplate) toKubePodSecurityContextV1() (*v1.PodSecurityContext, error) {
+	if pt.FSGID == nil && pt.GIDs == nil && pt.SELinux == nil && pt.UID == nil &&
+		pt.GID == nil && pt.ForceNonRoot == nil && pt.Sysctls == nil {
+		return nil, nil
+	}
+
+	securityContext := &v1.PodSecurityContext{
+		FSGroup:            pt.FSGID,
+		SupplementalGroups: pt.GIDs,
+		RunAsUser:          pt.UID,
+		RunAsGroup:         pt.GID,
+		RunAsNonRoot:       pt.ForceNonRoot,
+	}
+
+	if pt.SELinux != nil {
+		sel, err := pt.SELinux.ToKube("v1")
+		if err != nil {
+			return nil, objutil.ErrorAtPath(err, "selinux")
+		}
+		securityContext.SELinuxOptions = sel.(*v1.SELinuxOptions)
+	}
+
+	if pt.Sysctls != nil {
+		securityContext.Sysctls = []v1.Sysctl{}
+	}
+	for _, sysctl := range pt.Sysctls {
+		securityContext.Sysctls = append(securityContext.Sysctls, v1.Sysctl{
+			Name:  sysctl.Name,
+			Value: sysctl.Value,
+		})
+	}
+
+	return securityContext, nil
+}
+
 func (pt *PodTemplate) toKubeHostAliasesV1() ([]v1.HostAlias, error) {
 	var hostAliases []v1.HostAlias
 
-	for _, alias := range pt.HostAliases {
+	for i, alias := range pt.HostAliases {
 		hostAlias, err := alias.ToKube("v1")
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "host_aliases", i)
 		}
 
 		ha := hostAlias.(*v1.HostAlias)
@@ -161,10 +211,7 @@ func (pt *PodTemplate) toKubeHostAliasesV1() ([]v1.HostAlias, error) {
 
 func (pt *PodTemplate) toKubeRestartPolicyV1() (v1.RestartPolicy, error) {
 	switch pt.RestartPolicy {
-	case RestartPolicyDefault:
-		return "", nil
-
-	case RestartPolicyAlways:
+	case RestartPolicyDefault, RestartPolicyAlways:
 		return v1.RestartPolicyAlways, nil
 
 	case RestartPolicyOnFailure:
@@ -192,7 +239,7 @@ func (pt *PodTemplate) toKubeDNSPolicyV1() (v1.DNSPolicy, error) {
 		return v1.DNSNone, nil
 
 	case DNSUnset:
-		return "", nil
+		return v1.DNSClusterFirst, nil
 	}
 
 	return "", serrors.InvalidInstanceError(pt.DNSPolicy)
@@ -231,10 +278,10 @@ func (pt *PodTemplate) toKubeRegistriesV1() []v1.LocalObjectReference {
 func (pt *PodTemplate) toKubeTolerationsV1() ([]v1.Toleration, error) {
 	var tolerations []v1.Toleration
 
-	for _, t := range pt.Tolerations {
+	for i, t := range pt.Tolerations {
 		tol, err := t.ToKube("v1")
 		if err != nil {
-			return nil, err
+			return nil, objutil.ErrorAtPath(err, "tolerations", i)
 		}
 
 		tolV1 := tol.(*v1.Toleration)
@@ -285,24 +332,11 @@ func (pt *PodTemplate) toKubePodReadinessGatesV1() []v1.PodReadinessGate {
 	return readinessGates
 }
 
+// ToKubePodConditionTypeV1 converts a condition type, written by its
+// short name or as it is, to the kubernetes condition type
 func ToKubePodConditionTypeV1(cond PodConditionType) v1.PodConditionType {
-	switch cond {
-	case PodConditionScheduled:
-		return v1.PodScheduled
-
-	case PodConditionReady:
-		return v1.PodReady
-
-	case PodConditionInitialized:
-		return v1.PodInitialized
-
-	case PodConditionReasonUnschedulable:
-		return v1.PodReasonUnschedulable
-
-	case PodConditionContainersReady:
-		return v1.ContainersReady
-
-	default:
-		return ""
+	if kubeCond, ok := kubePodConditionTypes[cond]; ok {
+		return kubeCond
 	}
+	return v1.PodConditionType(cond)
 }
@@ -0,0 +1,151 @@
+package podtemplate
+
+import (
+	"mantle/pkg/core/pod/container"
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the pod template
+func (pt *PodTemplate) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	if len(pt.Containers) == 0 {
+		errs = append(errs, field.Required(field.NewPath("containers"), "a pod needs at least one container"))
+	}
+
+	names := map[string]bool{}
+	for _, list := range []struct {
+		fldPath    *field.Path
+		containers []container.Container
+	}{
+		{field.NewPath("init_containers"), pt.InitContainers},
+		{field.NewPath("containers"), pt.Containers},
+	} {
+		for i := range list.containers {
+			c := &list.containers[i]
+			fldPath := list.fldPath.Index(i)
+
+			errs = append(errs, validation.Prefix(fldPath, c.Validate())...)
+
+			if len(c.Name) > 0 {
+				if names[c.Name] {
+					errs = append(errs, field.Duplicate(fldPath.Child("name"), c.Name))
+				}
+				names[c.Name] = true
+			}
+
+			for j := range c.VolumeMounts {
+				if name := c.VolumeMounts[j].VolumeName(); len(name) > 0 {
+					if _, ok := pt.Volumes[name]; !ok {
+						errs = append(errs, field.NotFound(fldPath.Child("volume").Index(j).Child("store"), name))
+					}
+				}
+			}
+		}
+	}
+
+	for _, name := range pt.VolumeNames() {
+		v := pt.Volumes[name]
+		fldPath := field.NewPath("volumes").Child(name)
+		errs = append(errs, validation.DNS1123Label(fldPath, name)...)
+		errs = append(errs, validation.Prefix(fldPath, v.Validate())...)
+	}
+
+	errs = append(errs, pt.validateScheduling()...)
+	errs = append(errs, pt.validateSecurity()...)
+
+	if len(pt.Hostname) > 0 {
+		errs = append(errs, validation.DNS1123Subdomain(field.NewPath("hostname"), pt.Hostname)...)
+	}
+	for i, ip := range pt.Nameservers {
+		errs = append(errs, validation.IP(field.NewPath("nameservers").Index(i), ip)...)
+	}
+	for i := range pt.HostAliases {
+		errs = append(errs, validation.Prefix(field.NewPath("host_aliases").Index(i), pt.HostAliases[i].Validate())...)
+	}
+	for i, registry := range pt.Registries {
+		errs = append(errs, validation.DNS1123Subdomain(field.NewPath("registry_secrets").Index(i), registry)...)
+	}
+	for i, gate := range pt.Gates {
+		errs = append(errs, validation.QualifiedName(field.NewPath("gates").Index(i), string(ToKubePodConditionTypeV1(gate)))...)
+	}
+
+	return errs
+}
+
+func (pt *PodTemplate) validateScheduling() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validation.DNS1123Subdomain(field.NewPath("account"), pt.Account)...)
+	errs = append(errs, validation.DNS1123Subdomain(field.NewPath("priorityClass"), pt.PriorityClass)...)
+	errs = append(errs, validation.DNS1123Subdomain(field.NewPath("node"), pt.Node)...)
+	errs = append(errs, validation.Labels(field.NewPath("nodeSelector"), pt.NodeSelector)...)
+
+	if pt.Affinity != nil {
+		errs = append(errs, validation.Prefix(field.NewPath("affinity"), pt.Affinity.Validate())...)
+	}
+	for i := range pt.Tolerations {
+		errs = append(errs, validation.Prefix(field.NewPath("tolerations").Index(i), pt.Tolerations[i].Validate())...)
+	}
+
+	if pt.TerminationGracePeriod != nil && *pt.TerminationGracePeriod < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("termination_grace_period"), *pt.TerminationGracePeriod, "must not be negative"))
+	}
+	if pt.ActiveDeadline != nil && *pt.ActiveDeadline <= 0 {
+		errs = append(errs, field.Invalid(field.NewPath("active_deadline"), *pt.ActiveDeadline, "must be positive"))
+	}
+
+	return errs
+}
+
+func (pt *PodTemplate) validateSecurity() field.ErrorList {
+	var errs field.ErrorList
+
+	if len(pt.Seccomp) > 0 {
+		if err := container.CheckSeccompProfile(pt.Seccomp); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("seccomp"), pt.Seccomp, err.Error()))
+		}
+	}
+	if len(pt.AppArmor) > 0 {
+		if err := container.CheckAppArmorProfile(pt.AppArmor); err != nil {
+			errs = append(errs, field.Invalid(field.NewPath("apparmor"), pt.AppArmor, err.Error()))
+		}
+	}
+
+	switch pt.SecurityProfile {
+	case "", container.SecurityProfilePrivileged, container.SecurityProfileBaseline, container.SecurityProfileRestricted:
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("security_profile"), pt.SecurityProfile, []string{
+			string(container.SecurityProfilePrivileged), string(container.SecurityProfileBaseline), string(container.SecurityProfileRestricted),
+		}))
+	}
+
+	sysctls := map[string]bool{}
+	for i, sysctl := range pt.Sysctls {
+		fldPath := field.NewPath("sysctls").Index(i)
+		if len(sysctl.Name) == 0 {
+			errs = append(errs, field.Required(fldPath, "sysctl name must be set"))
+		}
+		if sysctls[sysctl.Name] {
+			errs = append(errs, field.Duplicate(fldPath, sysctl.Name))
+		}
+		sysctls[sysctl.Name] = true
+	}
+
+	for _, id := range []struct {
+		name  string
+		value *int64
+	}{
+		{"uid", pt.UID},
+		{"gid", pt.GID},
+		{"fs_gid", pt.FSGID},
+	} {
+		if id.value != nil && *id.value < 0 {
+			errs = append(errs, field.Invalid(field.NewPath(id.name), *id.value, "must not be negative"))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,83 @@
+package podtemplate
+
+import (
+	"reflect"
+	"testing"
+
+	"mantle/pkg/core/pod/container"
+	"mantle/pkg/core/pod/container/port"
+	"mantle/pkg/core/pod/container/volumemount"
+	"mantle/pkg/core/pod/volume"
+	"mantle/pkg/core/pod/volume/emptydir"
+	"mantle/pkg/core/pod/volume/hostpath"
+	"mantle/pkg/core/pod/volume/pvc"
+)
+
+func TestValidatePodTemplate(t *testing.T) {
+	pt := PodTemplate{
+		Volumes: map[string]volume.Volume{
+			"data":    {EmptyDir: &emptydir.EmptyDirVolume{}},
+			"host":    {HostPath: &hostpath.HostPathVolume{}},
+			"ledgers": {PVC: &pvc.PVCVolume{ClaimName: "Ledgers"}},
+		},
+		Containers: []container.Container{
+			{
+				Name:  "broker",
+				Image: "apachepulsar/pulsar",
+				VolumeMounts: []volumemount.VolumeMount{
+					{MountPath: "/data", Store: "data:broker"},
+				},
+			},
+			{
+				Name:  "broker",
+				Image: "apachepulsar/pulsar",
+				Expose: []port.Port{
+					{ContainerPort: "8080"},
+					{ContainerPort: "80000"},
+					{Name: "http", ContainerPort: "8080"},
+					{Name: "http", ContainerPort: "8081"},
+				},
+				VolumeMounts: []volumemount.VolumeMount{
+					{MountPath: "/logs", Store: "logs"},
+				},
+			},
+			{
+				Name: "Bookie",
+			},
+		},
+		Gates: []PodConditionType{PodConditionReady, "target-health.elbv2.k8s.aws/pulsar-proxy", "load balancer"},
+	}
+
+	var fields []string
+	for _, err := range pt.Validate() {
+		fields = append(fields, err.Type.String()+" "+err.Field)
+	}
+
+	expected := []string{
+		"Invalid value containers[1].expose[1]",
+		"Duplicate value containers[1].expose[3].name",
+		"Duplicate value containers[1].name",
+		"Not found containers[1].volume[0].store",
+		"Invalid value containers[2].name",
+		"Required value containers[2].image",
+		"Required value volumes.host.HostPath.Path",
+		"Invalid value volumes.ledgers.PVC.ClaimName",
+		"Invalid value gates[2]",
+	}
+
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected errors %v, got %v", expected, fields)
+	}
+}
+
+func TestValidateValidPodTemplate(t *testing.T) {
+	pt := PodTemplate{
+		Containers: []container.Container{
+			{Name: "broker", Image: "apachepulsar/pulsar", Expose: []port.Port{{ContainerPort: "6650"}}},
+		},
+	}
+
+	if errs := pt.Validate(); len(errs) > 0 {
+		t.Errorf("unexpected errors for a valid pod template: %v", errs)
+	}
+}
@@ -0,0 +1,109 @@
+package pod
+
+import (
+	"strings"
+
+	. "mantle/pkg/core/pod/container"
+	. "mantle/pkg/core/pod/podtemplate"
+
+	serrors "github.com/koki/structurederrors"
+)
+
+// On this kubernetes version, seccomp and AppArmor profiles are set
+// through annotations on the pod's metadata rather than in the spec.
+const (
+	seccompPodAnnotation              = "seccomp.security.alpha.kubernetes.io/pod"
+	seccompContainerAnnotationPrefix  = "container.seccomp.security.alpha.kubernetes.io/"
+	appArmorContainerAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+)
+
+func fromKubeSecurityAnnotationsV1(pod *Pod) {
+	if len(pod.Annotations) == 0 {
+		return
+	}
+
+	containers := map[string]*Container{}
+	for i := range pod.InitContainers {
+		containers[pod.InitContainers[i].Name] = &pod.InitContainers[i]
+	}
+	for i := range pod.Containers {
+		containers[pod.Containers[i].Name] = &pod.Containers[i]
+	}
+
+	// Copy the annotations so the kubernetes object isn't modified.
+	annotations := map[string]string{}
+	for key, value := range pod.Annotations {
+		if key == seccompPodAnnotation {
+			pod.Seccomp = value
+			continue
+		}
+
+		if strings.HasPrefix(key, seccompContainerAnnotationPrefix) {
+			if container, ok := containers[strings.TrimPrefix(key, seccompContainerAnnotationPrefix)]; ok {
+				container.Seccomp = value
+				continue
+			}
+		}
+
+		if strings.HasPrefix(key, appArmorContainerAnnotationPrefix) {
+			if container, ok := containers[strings.TrimPrefix(key, appArmorContainerAnnotationPrefix)]; ok {
+				container.AppArmor = value
+				continue
+			}
+		}
+
+		annotations[key] = value
+	}
+
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	pod.Annotations = annotations
+}
+
+func toKubeSecurityAnnotationsV1(podAnnotations map[string]string, template *PodTemplate) (map[string]string, error) {
+	annotations := map[string]string{}
+	for key, value := range podAnnotations {
+		annotations[key] = value
+	}
+
+	if len(template.Seccomp) > 0 {
+		if err := CheckSeccompProfile(template.Seccomp); err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "pod seccomp")
+		}
+		annotations[seccompPodAnnotation] = template.Seccomp
+	}
+
+	if len(template.AppArmor) > 0 {
+		if err := CheckAppArmorProfile(template.AppArmor); err != nil {
+			return nil, serrors.ContextualizeErrorf(err, "pod apparmor")
+		}
+	}
+
+	containers := append(append([]Container{}, template.InitContainers...), template.Containers...)
+	for _, container := range containers {
+		if len(container.Seccomp) > 0 {
+			if err := CheckSeccompProfile(container.Seccomp); err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "container (%s) seccomp", container.Name)
+			}
+			annotations[seccompContainerAnnotationPrefix+container.Name] = container.Seccomp
+		}
+
+		appArmor := container.AppArmor
+		if len(appArmor) == 0 {
+			appArmor = template.AppArmor
+		}
+		if len(appArmor) > 0 {
+			if err := CheckAppArmorProfile(appArmor); err != nil {
+				return nil, serrors.ContextualizeErrorf(err, "container (%s) apparmor", container.Name)
+			}
+			annotations[appArmorContainerAnnotationPrefix+container.Name] = appArmor
+		}
+	}
+
+	if len(annotations) == 0 {
+		return nil, nil
+	}
+
+	return annotations, nil
+}
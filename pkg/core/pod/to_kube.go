@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"strings"
 
+	"mantle/pkg/core/pod/container"
 	"mantle/pkg/core/pod/podtemplate"
+	"mantle/pkg/util/objutil"
 
 	"k8s.io/api/core/v1"
 
@@ -32,14 +34,24 @@ func (pod *Pod) toKubeV1() (*v1.Pod, error) {
 	kubePod.APIVersion = pod.Version
 	kubePod.Kind = "Pod"
 
-	meta, err := pod.PodTemplateMeta.ToKube(pod.Version)
+	objectMeta, err := pod.Meta.ToKube(pod.Version)
 	if err != nil {
 		return nil, err
 	}
-	metaV1 := meta.(*metav1.ObjectMeta)
+	metaV1 := objectMeta.(*metav1.ObjectMeta)
 	kubePod.ObjectMeta = *metaV1
 
-	spec, err := pod.PodTemplate.ToKube(pod.Version)
+	template, err := pod.PodTemplate.ExpandSecurityProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	kubePod.Annotations, err = toKubeSecurityAnnotationsV1(kubePod.Annotations, template)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := template.ToKube(pod.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -56,31 +68,22 @@ func (pod *Pod) toKubeV1() (*v1.Pod, error) {
 	kubePod.Status.StartTime = pod.StartTime
 	kubePod.Status.Conditions = pod.toKubePodConditionV1()
 
-	var initContainerStatuses []v1.ContainerStatus
-	for _, container := range pod.InitContainers {
-		s, err := container.ToKubeStatus("v1")
-		if err != nil {
-			return nil, err
-		}
-		status := s.(v1.ContainerStatus)
-		initContainerStatuses = append(initContainerStatuses, status)
+	initContainerStatuses, err := toKubeContainerStatusesV1(pod.InitContainers)
+	if err != nil {
+		return nil, objutil.ErrorAtPath(err, "init_containers")
 	}
 	kubePod.Status.InitContainerStatuses = initContainerStatuses
 
-	var containerStatuses []v1.ContainerStatus
-	for _, container := range pod.Containers {
-		s, err := container.ToKubeStatus("v1")
-		if err != nil {
-			return nil, err
-		}
-
-		status := s.(v1.ContainerStatus)
-		if status.ContainerID != "" {
-			containerStatuses = append(containerStatuses, status)
-		}
+	containerStatuses, err := toKubeContainerStatusesV1(pod.Containers)
+	if err != nil {
+		return nil, objutil.ErrorAtPath(err, "containers")
 	}
 	kubePod.Status.ContainerStatuses = containerStatuses
 
+	if err := pod.Extra.Apply(kubePod); err != nil {
+		return nil, objutil.ErrorAtPath(err, "extra")
+	}
+
 	return kubePod, nil
 }
 
@@ -146,3 +149,28 @@ func (pod *Pod) toKubeConditionStatusV1(status ConditionStatus) v1.ConditionStat
 		return ""
 	}
 }
+
+// toKubeContainerStatusesV1 returns the statuses of the containers that
+// have one
+func toKubeContainerStatusesV1(containers []container.Container) ([]v1.ContainerStatus, error) {
+	var statuses []v1.ContainerStatus
+	for i, c := range containers {
+		if !hasStatus(c) {
+			continue
+		}
+
+		s, err := c.ToKubeStatus("v1")
+		if err != nil {
+			return nil, objutil.ErrorAtPath(err, i)
+		}
+		statuses = append(statuses, s.(v1.ContainerStatus))
+	}
+	return statuses, nil
+}
+
+// hasStatus reports whether kubernetes reported a status for the
+// container. Waiting containers don't have an ID yet.
+func hasStatus(c container.Container) bool {
+	return len(c.ContainerID) > 0 || len(c.ImageID) > 0 || c.CurrentState != nil ||
+		c.LastState != nil || c.Restarts > 0 || c.Ready
+}
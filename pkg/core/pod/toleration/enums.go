@@ -0,0 +1,14 @@
+package toleration
+
+import (
+	"mantle/pkg/util/enum"
+)
+
+//go:generate go run mantle/internal/enumgen
+
+// The names that enums are written as. See enum.Names.
+// The methods that read and write them are generated from them.
+var (
+	tolerationOperatorNames = enum.Names{"exists", "equal"}
+	taintEffectNames        = enum.Names{"no-schedule", "prefer-no-schedule", "no-execute", "all"}
+)
@@ -0,0 +1,63 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package toleration
+
+// EnumNames returns the names of the TolerationOperator values
+func (TolerationOperator) EnumNames() []string {
+	return tolerationOperatorNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t TolerationOperator) MarshalText() ([]byte, error) {
+	return tolerationOperatorNames.Text(int(t))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *TolerationOperator) UnmarshalText(text []byte) error {
+	value, err := tolerationOperatorNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*t = TolerationOperator(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (t *TolerationOperator) UnmarshalJSON(data []byte) error {
+	value, err := tolerationOperatorNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*t = TolerationOperator(value)
+	return nil
+}
+
+// EnumNames returns the names of the TaintEffect values
+func (TaintEffect) EnumNames() []string {
+	return taintEffectNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t TaintEffect) MarshalText() ([]byte, error) {
+	return taintEffectNames.Text(int(t))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *TaintEffect) UnmarshalText(text []byte) error {
+	value, err := taintEffectNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*t = TaintEffect(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (t *TaintEffect) UnmarshalJSON(data []byte) error {
+	value, err := taintEffectNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*t = TaintEffect(value)
+	return nil
+}
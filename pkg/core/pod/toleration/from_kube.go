@@ -49,6 +49,9 @@ func fromKubeTolerationV1(tol v1.Toleration) (*Toleration, error) {
 	case v1.TaintEffectNoExecute:
 		toleration.Effect = TaintEffectNoExecute
 
+	case "":
+		toleration.Effect = TaintEffectAll
+
 	default:
 		return nil, fmt.Errorf("unrecognized effect in toleration: %v", tol)
 	}
@@ -47,6 +47,9 @@ func (t *Toleration) toKubeV1() (*v1.Toleration, error) {
 	case TaintEffectNoExecute:
 		toleration.Effect = v1.TaintEffectNoExecute
 		
+	case TaintEffectAll:
+		toleration.Effect = ""
+
 	default:
 		return nil, fmt.Errorf("unrecognized effect in toleration: %v", t)
 	}
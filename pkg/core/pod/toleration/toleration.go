@@ -21,4 +21,7 @@ const (
 	TaintEffectNoSchedule TaintEffect = iota
 	TaintEffectPreferNoSchedule
 	TaintEffectNoExecute
+	// TaintEffectAll tolerates every effect. Kubernetes writes it as
+	// an empty effect.
+	TaintEffectAll
 )
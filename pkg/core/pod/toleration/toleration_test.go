@@ -0,0 +1,46 @@
+package toleration
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/api/core/v1"
+)
+
+func TestTolerationRoundTrip(t *testing.T) {
+	seconds := int64(300)
+	tests := []v1.Toleration{
+		{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "pulsar", Effect: v1.TaintEffectNoSchedule},
+		{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute, TolerationSeconds: &seconds},
+		// An empty effect tolerates every effect.
+		{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "pulsar"},
+		{Operator: v1.TolerationOpExists},
+	}
+
+	for _, kubeToleration := range tests {
+		toleration, err := NewTolerationFromKubeToleration(kubeToleration)
+		if err != nil {
+			t.Errorf("%+v: unexpected error: %v", kubeToleration, err)
+			continue
+		}
+		if len(kubeToleration.Effect) == 0 && toleration.Effect != TaintEffectAll {
+			t.Errorf("%+v: expected every effect, got %v", kubeToleration, toleration.Effect)
+		}
+
+		converted, err := toleration.ToKube("v1")
+		if err != nil {
+			t.Errorf("%+v: unexpected error: %v", kubeToleration, err)
+			continue
+		}
+		if !reflect.DeepEqual(*converted.(*v1.Toleration), kubeToleration) {
+			t.Errorf("expected %+v, got %+v", kubeToleration, *converted.(*v1.Toleration))
+		}
+	}
+}
+
+func TestUnrecognizedEffect(t *testing.T) {
+	_, err := NewTolerationFromKubeToleration(v1.Toleration{Operator: v1.TolerationOpExists, Effect: "NoRun"})
+	if err == nil {
+		t.Errorf("expected an error for an unrecognized effect")
+	}
+}
@@ -0,0 +1,33 @@
+package toleration
+
+import (
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the toleration
+func (t *Toleration) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validation.QualifiedName(field.NewPath("key"), t.Key)...)
+
+	switch t.Op {
+	case TolerationOperatorExists:
+		if len(t.Value) > 0 {
+			errs = append(errs, field.Invalid(field.NewPath("value"), t.Value, "must be empty when op is Exists"))
+		}
+	case TolerationOperatorEqual:
+		if len(t.Key) == 0 {
+			errs = append(errs, field.Required(field.NewPath("key"), "key must be set when op is Equal"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("op"), t.Op, []string{"Exists", "Equal"}))
+	}
+
+	if t.ExpirationSeconds != nil && t.Effect != TaintEffectNoExecute {
+		errs = append(errs, field.Invalid(field.NewPath("expirationSeconds"), *t.ExpirationSeconds, "only applies to the NoExecute effect"))
+	}
+
+	return errs
+}
@@ -0,0 +1,15 @@
+package pod
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the pod
+func (pod *Pod) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, pod.Meta.Validate()...)
+	errs = append(errs, pod.PodTemplate.Validate()...)
+
+	return errs
+}
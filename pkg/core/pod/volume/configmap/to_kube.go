@@ -36,7 +36,7 @@ func (s *ConfigMapVolume) toKubeV1() (*v1.Volume, error) {
 			ConfigMap: &v1.ConfigMapVolumeSource{
 				LocalObjectReference: *ref,
 				Items:                util.NewKubeKeyToPathV1(s.Items),
-				DefaultMode:          util.ConvertFileModeToInt32Ptr(s.DefaultMode),
+				DefaultMode:          util.ConvertDefaultModeToInt32Ptr(s.DefaultMode),
 				Optional:             converterutils.RequiredToOptional(s.Required),
 			},
 		},
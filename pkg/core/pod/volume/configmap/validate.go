@@ -0,0 +1,17 @@
+package configmap
+
+import (
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the config map volume
+func (c *ConfigMapVolume) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validation.Required(field.NewPath("Name"), c.Name)...)
+	errs = append(errs, validation.DNS1123Subdomain(field.NewPath("Name"), c.Name)...)
+
+	return errs
+}
@@ -32,7 +32,7 @@ func (s *DownwardAPIVolume) toKubeV1() (*v1.Volume, error) {
 		VolumeSource: v1.VolumeSource{
 			DownwardAPI: &v1.DownwardAPIVolumeSource{
 				Items:       items,
-				DefaultMode: util.ConvertFileModeToInt32Ptr(s.DefaultMode),
+				DefaultMode: util.ConvertDefaultModeToInt32Ptr(s.DefaultMode),
 			},
 		},
 	}, nil
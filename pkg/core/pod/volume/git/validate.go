@@ -0,0 +1,12 @@
+package git
+
+import (
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the git volume
+func (g *GitVolume) Validate() field.ErrorList {
+	return validation.Required(field.NewPath("Repository"), g.Repository)
+}
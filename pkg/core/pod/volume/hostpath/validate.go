@@ -0,0 +1,26 @@
+package hostpath
+
+import (
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the host path volume
+func (h *HostPathVolume) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validation.Required(field.NewPath("Path"), h.Path)...)
+
+	switch h.Type {
+	case HostPathUnset, HostPathDirectoryOrCreate, HostPathDirectory, HostPathFileOrCreate,
+		HostPathFile, HostPathSocket, HostPathCharDev, HostPathBlockDev:
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("Type"), h.Type, []string{
+			string(HostPathDirectoryOrCreate), string(HostPathDirectory), string(HostPathFileOrCreate),
+			string(HostPathFile), string(HostPathSocket), string(HostPathCharDev), string(HostPathBlockDev),
+		}))
+	}
+
+	return errs
+}
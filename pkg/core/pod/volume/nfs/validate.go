@@ -0,0 +1,17 @@
+package nfs
+
+import (
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the nfs volume
+func (n *NFSVolume) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validation.Required(field.NewPath("Server"), n.Server)...)
+	errs = append(errs, validation.Required(field.NewPath("Path"), n.Path)...)
+
+	return errs
+}
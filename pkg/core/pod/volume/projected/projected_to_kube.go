@@ -33,7 +33,7 @@ func (s *ProjectedVolume) toKubeV1() (*v1.Volume, error) {
 		VolumeSource: v1.VolumeSource{
 			Projected: &v1.ProjectedVolumeSource{
 				Sources:     sources,
-				DefaultMode: util.ConvertFileModeToInt32Ptr(s.DefaultMode),
+				DefaultMode: util.ConvertDefaultModeToInt32Ptr(s.DefaultMode),
 			},
 		},
 	}, nil
@@ -0,0 +1,17 @@
+package pvc
+
+import (
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the persistent volume claim volume
+func (p *PVCVolume) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validation.Required(field.NewPath("ClaimName"), p.ClaimName)...)
+	errs = append(errs, validation.DNS1123Subdomain(field.NewPath("ClaimName"), p.ClaimName)...)
+
+	return errs
+}
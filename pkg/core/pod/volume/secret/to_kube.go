@@ -29,7 +29,7 @@ func (s *SecretVolume) toKubeV1() (*v1.Volume, error) {
 			Secret: &v1.SecretVolumeSource{
 				SecretName:  s.SecretName,
 				Items:       util.NewKubeKeyToPathV1(s.Items),
-				DefaultMode: util.ConvertFileModeToInt32Ptr(s.DefaultMode),
+				DefaultMode: util.ConvertDefaultModeToInt32Ptr(s.DefaultMode),
 				Optional:    converterutils.RequiredToOptional(s.Required),
 			},
 		},
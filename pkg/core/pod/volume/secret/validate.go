@@ -0,0 +1,17 @@
+package secret
+
+import (
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Validate returns every problem with the secret volume
+func (s *SecretVolume) Validate() field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validation.Required(field.NewPath("SecretName"), s.SecretName)...)
+	errs = append(errs, validation.DNS1123Subdomain(field.NewPath("SecretName"), s.SecretName)...)
+
+	return errs
+}
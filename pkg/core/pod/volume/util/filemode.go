@@ -28,3 +28,16 @@ func NewFileModeFromKubeV1(kubeMode *int32) *FileMode {
 	return FileModePtr(FileMode(*kubeMode))
 }
 
+// DefaultMode is the mode kubernetes gives the files of a volume that
+// doesn't set one
+const DefaultMode FileMode = 0644
+
+// ConvertDefaultModeToInt32Ptr converts the default mode of a volume,
+// filling in DefaultMode if it's unset
+func ConvertDefaultModeToInt32Ptr(mode *FileMode) *int32 {
+	if mode == nil {
+		return util.Int32Ptr(int32(DefaultMode))
+	}
+
+	return ConvertFileModeToInt32Ptr(mode)
+}
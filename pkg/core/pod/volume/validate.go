@@ -0,0 +1,62 @@
+package volume
+
+import (
+	"reflect"
+
+	"mantle/pkg/util/validation"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// validator is implemented by the volume sources that validate their
+// settings, e.g. that a hostPath volume has a path
+type validator interface {
+	Validate() field.ErrorList
+}
+
+// Validate checks that the volume has exactly one source, and the
+// settings of that source
+func (v *Volume) Validate() field.ErrorList {
+	switch sources := v.sources(); sources {
+	case 0:
+		return field.ErrorList{field.Required(nil, "volume source must be set")}
+	case 1:
+	default:
+		return field.ErrorList{field.Invalid(nil, sources, "volume must have exactly one source")}
+	}
+
+	fields := reflect.ValueOf(v).Elem()
+	for n := 0; n < fields.NumField(); n++ {
+		source := fields.Field(n)
+		if source.IsNil() {
+			continue
+		}
+
+		if source, ok := source.Interface().(validator); ok {
+			return validation.Prefix(field.NewPath(fields.Type().Field(n).Name), source.Validate())
+		}
+	}
+
+	return nil
+}
+
+// HasSource reports whether the volume has a source mantle models. A
+// kubernetes volume converts to a volume without one if its type isn't
+// known, e.g. csi, which the kubernetes API mantle is built against
+// drops when parsing.
+func (v *Volume) HasSource() bool {
+	return v.sources() > 0
+}
+
+func (v *Volume) sources() int {
+	sources := 0
+
+	fields := reflect.ValueOf(v).Elem()
+	for n := 0; n < fields.NumField(); n++ {
+		if field := fields.Field(n); !field.IsNil() {
+			sources++
+		}
+	}
+
+	return sources
+}
@@ -0,0 +1,33 @@
+// Code generated by enumgen. DO NOT EDIT.
+
+package protocol
+
+// EnumNames returns the names of the Protocol values
+func (Protocol) EnumNames() []string {
+	return protocolNames
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (p Protocol) MarshalText() ([]byte, error) {
+	return protocolNames.Text(int(p))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (p *Protocol) UnmarshalText(text []byte) error {
+	value, err := protocolNames.ParseText(text)
+	if err != nil {
+		return err
+	}
+	*p = Protocol(value)
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (p *Protocol) UnmarshalJSON(data []byte) error {
+	value, err := protocolNames.ParseJSON(data)
+	if err != nil {
+		return err
+	}
+	*p = Protocol(value)
+	return nil
+}
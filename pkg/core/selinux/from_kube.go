@@ -7,7 +7,7 @@ import (
 	"k8s.io/api/core/v1"
 )
 
-// NeNewSELinuxFromKubeSELinuxOptions will create a new
+// NewSELinuxFromKubeSELinuxOptions will create a new
 // SELinux object with the data from a provided kubernetes
 // SELinuxOptions object
 func NewSELinuxFromKubeSELinuxOptions(obj interface{}) (*SELinux, error) {
@@ -23,6 +23,10 @@ func NewSELinuxFromKubeSELinuxOptions(obj interface{}) (*SELinux, error) {
 }
 
 func fromSELinuxOptionsV1(opts *v1.SELinuxOptions) (*SELinux, error) {
+	if opts == nil {
+		return nil, nil
+	}
+
 	return &SELinux{
 		User:  opts.User,
 		Level: opts.Level,
@@ -0,0 +1,58 @@
+package selinux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+type selinuxFields struct {
+	Level string `json:"level,omitempty"`
+	Role  string `json:"role,omitempty"`
+	Type  string `json:"type,omitempty"`
+	User  string `json:"user,omitempty"`
+}
+
+// String returns the user:role:type:level form of the label
+func (s *SELinux) String() string {
+	return fmt.Sprintf("%s:%s:%s:%s", s.User, s.Role, s.Type, s.Level)
+}
+
+// InitFromString sets the label from its user:role:type:level form.
+// The level may contain colons of its own (e.g. s0:c1,c2).
+func (s *SELinux) InitFromString(str string) error {
+	fields := strings.SplitN(str, ":", 4)
+	if len(fields) != 4 {
+		return serrors.InvalidValueErrorf(str, "expected user:role:type:level")
+	}
+
+	s.User = fields[0]
+	s.Role = fields[1]
+	s.Type = fields[2]
+	s.Level = fields[3]
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaller interface.
+func (s SELinux) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaller interface.
+func (s *SELinux) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		return s.InitFromString(str)
+	}
+
+	fields := selinuxFields{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return serrors.InvalidValueForTypeErrorf(string(data), s, "expected user:role:type:level or {user, role, type, level}")
+	}
+
+	*s = SELinux(fields)
+	return nil
+}
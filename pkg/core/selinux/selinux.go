@@ -1,5 +1,8 @@
 package selinux
 
+// SELinux defines an SELinux label. It's written as a
+// user:role:type:level string, e.g. system_u:system_r:svirt_lxc_net_t:s0:c123,c456
+// Empty components are left blank, e.g. ::spc_t:
 type SELinux struct {
 	Level string `json:"level,omitempty"`
 	Role  string `json:"role,omitempty"`
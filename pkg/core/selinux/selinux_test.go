@@ -0,0 +1,54 @@
+package selinux
+
+import (
+	"testing"
+
+	"github.com/koki/json"
+)
+
+func TestSELinuxString(t *testing.T) {
+	testCases := []struct {
+		str      string
+		expected SELinux
+	}{
+		{"system_u:system_r:svirt_lxc_net_t:s0:c123,c456", SELinux{User: "system_u", Role: "system_r", Type: "svirt_lxc_net_t", Level: "s0:c123,c456"}},
+		{"::spc_t:", SELinux{Type: "spc_t"}},
+		{":::s0", SELinux{Level: "s0"}},
+	}
+
+	for _, testCase := range testCases {
+		var s SELinux
+		if err := json.Unmarshal([]byte(`"`+testCase.str+`"`), &s); err != nil {
+			t.Errorf("%s: unexpected error: %v", testCase.str, err)
+			continue
+		}
+		if s != testCase.expected {
+			t.Errorf("%s: expected %+v, got %+v", testCase.str, testCase.expected, s)
+		}
+
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", testCase.str, err)
+			continue
+		}
+		if result := string(data); result != `"`+testCase.str+`"` {
+			t.Errorf("%s: expected it to be written back as it was, got %s", testCase.str, result)
+		}
+	}
+}
+
+func TestSELinuxFields(t *testing.T) {
+	var s SELinux
+	if err := json.Unmarshal([]byte(`{"type": "spc_t", "level": "s0"}`), &s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := (SELinux{Type: "spc_t", Level: "s0"}); s != expected {
+		t.Errorf("expected %+v, got %+v", expected, s)
+	}
+
+	for _, invalid := range []string{`"spc_t"`, `"system_u:system_r"`, `42`} {
+		if err := json.Unmarshal([]byte(invalid), &s); err == nil {
+			t.Errorf("%s: expected an error", invalid)
+		}
+	}
+}
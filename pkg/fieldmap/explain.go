@@ -0,0 +1,90 @@
+package fieldmap
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"mantle/pkg/schema"
+)
+
+// Explain writes a description of the field: its type, allowed values
+// or shorthand forms from the schema, its default, the kubernetes
+// fields it maps to, and the fields under it.
+func Explain(w io.Writer, field Field, index *schema.Index) error {
+	schemas := index.At(strings.Split(field.Path, "."))
+
+	var lines [][2]string
+	add := func(label string, values ...string) {
+		for i, value := range values {
+			if i > 0 {
+				label = ""
+			}
+			lines = append(lines, [2]string{label, value})
+		}
+	}
+
+	if t := index.Describe(schemas); len(t) > 0 {
+		add("type", t)
+	}
+	if values := index.Values(schemas); len(values) > 0 {
+		add("values", strings.Join(values, ", "))
+	}
+	add("shorthand", index.Forms(schemas)...)
+	if len(field.Default) > 0 {
+		add("default", field.Default)
+	}
+	if len(field.Kube) > 0 {
+		add("kubernetes", field.Kube...)
+	} else {
+		add("kubernetes", "(no field of its own)")
+	}
+
+	var children []string
+	for _, child := range Children(field) {
+		segments := strings.Split(child.Path, ".")
+		children = append(children, segments[len(segments)-1])
+	}
+	if len(children) == 0 {
+		children = index.Properties(schemas)
+	}
+	if len(children) > 0 {
+		add("fields", wrap(children, 60)...)
+	}
+
+	if _, err := fmt.Fprintf(w, "%s\n", field.Path); err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintf(w, "  %-11s %s\n", line[0], line[1]); err != nil {
+			return err
+		}
+	}
+	if len(field.Doc) > 0 {
+		if _, err := fmt.Fprintf(w, "\n  %s\n", field.Doc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// wrap joins words with commas into lines of about the given width
+func wrap(words []string, width int) []string {
+	var lines []string
+	line := ""
+	for i, word := range words {
+		if i < len(words)-1 {
+			word += ","
+		}
+		if len(line) > 0 && len(line)+1+len(word) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if len(line) > 0 {
+			line += " "
+		}
+		line += word
+	}
+	return append(lines, line)
+}
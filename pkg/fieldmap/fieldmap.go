@@ -0,0 +1,427 @@
+// Package fieldmap maps the fields of mantle objects to the kubernetes
+// fields they're converted to. It's the reference that tools like the
+// language server and mantle explain use to explain a mantle file in
+// kubernetes terms.
+//
+// The table mirrors the to_kube.go and from_kube.go converters of each
+// mantle type; when a converter maps a field differently, update its
+// entry here too.
+package fieldmap
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Wildcard matches any sequence index or map key in a field path
+const Wildcard = "*"
+
+// Field describes a field of a mantle object
+type Field struct {
+	// Path is the dot-separated mantle path, including the kind
+	// envelope, e.g. pod.containers.*.cpu
+	Path string
+	// Kube lists the kubernetes fields the field is converted to,
+	// e.g. spec.containers[*].resources.requests.cpu
+	Kube []string
+	// Doc is a short description of the field
+	Doc string
+	// Default describes the value used when the field is left out
+	Default string
+}
+
+// Fields returns every mapped field, in table order
+func Fields() []Field {
+	return fields
+}
+
+// Lookup returns the field at the given mantle path. Sequence indices
+// and map keys in the path match the wildcards in the table, e.g.
+// pod.containers.0.cpu finds pod.containers.*.cpu.
+func Lookup(path ...string) (*Field, bool) {
+	for i := range fields {
+		if matchPath(strings.Split(fields[i].Path, "."), path) {
+			return &fields[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// Find returns the fields matching a query, for looking fields up by
+// hand. Query segments match ignoring case and plurals (volume matches
+// volumes, probe matches liveness_probe), wildcards and indices may be left out (pod.containers.cpu),
+// and the query may start at any depth (cpu.min). If some fields match
+// the query from the kind down, only those are returned.
+func Find(query string) []Field {
+	var q []string
+	for _, segment := range strings.Split(strings.Trim(query, "."), ".") {
+		if _, err := strconv.Atoi(segment); err != nil && segment != Wildcard {
+			q = append(q, segment)
+		}
+	}
+	if len(q) == 0 {
+		return nil
+	}
+
+	// Prefer the fields whose names match outright over those that
+	// only match the end of a name, e.g. containers over init_containers.
+	for _, fuzzy := range []bool{false, true} {
+		if found := find(q, fuzzy); len(found) > 0 {
+			return found
+		}
+	}
+	return nil
+}
+
+func find(q []string, fuzzy bool) []Field {
+	var exact, partial []Field
+	for _, field := range fields {
+		segments := named(field.Path)
+		if len(segments) < len(q) {
+			continue
+		}
+
+		suffix := segments[len(segments)-len(q):]
+		match := true
+		for i := range q {
+			match = match && matchSegment(suffix[i], q[i], fuzzy)
+		}
+		if !match {
+			continue
+		}
+
+		if len(segments) == len(q) {
+			exact = append(exact, field)
+		} else {
+			partial = append(partial, field)
+		}
+	}
+
+	if len(exact) > 0 {
+		return exact
+	}
+	return partial
+}
+
+// Children returns the fields directly under a field, e.g. the fields
+// of each container for pod.containers
+func Children(field Field) []Field {
+	depth := len(named(field.Path))
+
+	var children []Field
+	for _, child := range fields {
+		if strings.HasSuffix(child.Path, "."+Wildcard) {
+			continue
+		}
+		if strings.HasPrefix(child.Path, field.Path+".") && len(named(child.Path)) == depth+1 {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+// named returns the segments of a field path, without its wildcards
+func named(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, ".") {
+		if segment != Wildcard {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func matchSegment(segment, query string, fuzzy bool) bool {
+	segment, query = strings.ToLower(segment), strings.ToLower(query)
+	if segment == query || segment == query+"s" {
+		return true
+	}
+	return fuzzy && strings.HasSuffix(segment, "_"+query)
+}
+
+func matchPath(pattern, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+
+	for i := range pattern {
+		if pattern[i] != Wildcard && pattern[i] != path[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+var fields = buildFields()
+
+func buildFields() []Field {
+	var fs []Field
+
+	fs = append(fs, metaFields("pod", "Pod")...)
+	fs = append(fs, podFields...)
+	fs = append(fs, volumeFields()...)
+	fs = append(fs, selinuxFields("pod.selinux", "spec.securityContext.seLinuxOptions")...)
+	fs = append(fs, tolerationFields...)
+	fs = append(fs, containerFields("pod.init_containers.*", "spec.initContainers[*]", "status.initContainerStatuses[*]")...)
+	fs = append(fs, containerFields("pod.containers.*", "spec.containers[*]", "status.containerStatuses[*]")...)
+	fs = append(fs, metaFields("config_map", "ConfigMap")...)
+	fs = append(fs, configMapFields...)
+
+	return fs
+}
+
+func metaFields(kind, kubeKind string) []Field {
+	return []Field{
+		{Path: kind, Kube: []string{kubeKind}, Doc: "a " + kubeKind + " object"},
+		{Path: kind + ".version", Kube: []string{"apiVersion"}, Doc: "the kubernetes API version, e.g. v1"},
+		{Path: kind + ".cluster", Kube: []string{"metadata.clusterName"}, Doc: "the cluster the object belongs to"},
+		{Path: kind + ".name", Kube: []string{"metadata.name"}, Doc: "the name of the object"},
+		{Path: kind + ".generate_name", Kube: []string{"metadata.generateName"}, Doc: "a prefix kubernetes generates a unique name from, if there's no name"},
+		{Path: kind + ".namespace", Kube: []string{"metadata.namespace"}, Doc: "the namespace of the object"},
+		{Path: kind + ".labels", Kube: []string{"metadata.labels"}, Doc: "labels for selecting the object"},
+		{Path: kind + ".labels.*", Kube: []string{"metadata.labels[*]"}, Doc: "a label"},
+		{Path: kind + ".annotations", Kube: []string{"metadata.annotations"}, Doc: "non-identifying metadata"},
+		{Path: kind + ".annotations.*", Kube: []string{"metadata.annotations[*]"}, Doc: "an annotation"},
+		{Path: kind + ".owner_refs", Kube: []string{"metadata.ownerReferences"}, Doc: "the objects that own the object, which is deleted along with them"},
+		{Path: kind + ".owner_refs.*", Kube: []string{"metadata.ownerReferences[*]"}, Doc: "an owner, with its version, kind, name and uid. kind/name, followed by controller if it manages the object, leaves out the version and uid kubernetes needs"},
+		{Path: kind + ".finalizers", Kube: []string{"metadata.finalizers"}, Doc: "what must happen before the object is deleted"},
+		{Path: kind + ".object_uid", Kube: []string{"metadata.uid"}, Doc: "the unique ID of the object", Default: "set by kubernetes"},
+		{Path: kind + ".resource_version", Kube: []string{"metadata.resourceVersion"}, Doc: "the version of the object, for optimistic concurrency", Default: "set by kubernetes"},
+		{Path: kind + ".created", Kube: []string{"metadata.creationTimestamp"}, Doc: "when the object was created", Default: "set by kubernetes"},
+		{Path: kind + ".deleted", Kube: []string{"metadata.deletionTimestamp"}, Doc: "when the object will be deleted", Default: "set by kubernetes"},
+		{Path: kind + ".extra", Doc: "fields of the " + kubeKind + " that mantle doesn't model, added to the generated object"},
+	}
+}
+
+var podFields = []Field{
+	{Path: "pod.volumes", Kube: []string{"spec.volumes"}, Doc: "the volumes of the pod, by name"},
+	{Path: "pod.volumes.*", Kube: []string{"spec.volumes[*]"}, Doc: "a volume, named by its key"},
+	{Path: "pod.init_containers", Kube: []string{"spec.initContainers"}, Doc: "containers run in order before the app containers"},
+	{Path: "pod.containers", Kube: []string{"spec.containers"}, Doc: "the app containers of the pod"},
+	{Path: "pod.restart_policy", Kube: []string{"spec.restartPolicy"}, Doc: "when containers are restarted", Default: "default, which is always"},
+	{Path: "pod.termination_grace_period", Kube: []string{"spec.terminationGracePeriodSeconds"}, Doc: "seconds the pod has to stop gracefully", Default: "30, left out when converting from kubernetes"},
+	{Path: "pod.active_deadline", Kube: []string{"spec.activeDeadlineSeconds"}, Doc: "seconds the pod may run before it's stopped"},
+	{Path: "pod.dns_policy", Kube: []string{"spec.dnsPolicy"}, Doc: "how the pod's DNS is configured", Default: "unset, which is cluster-first"},
+	{Path: "pod.nodeSelector", Kube: []string{"spec.nodeSelector"}, Doc: "labels of the nodes the pod may run on"},
+	{Path: "pod.account", Kube: []string{"spec.serviceAccountName"}, Doc: "the service account the pod runs as", Default: "default, set by kubernetes"},
+	{Path: "pod.automountAccountToken", Kube: []string{"spec.automountServiceAccountToken"}, Doc: "whether the service account token is mounted", Default: "true, left out when converting from kubernetes"},
+	{Path: "pod.node", Kube: []string{"spec.nodeName"}, Doc: "the node the pod is bound to"},
+	{Path: "pod.host_mode", Kube: []string{"spec.hostNetwork", "spec.hostPID", "spec.hostIPC"}, Doc: "host namespaces the pod shares"},
+	{Path: "pod.shareNamespace", Kube: []string{"spec.shareProcessNamespace"}, Doc: "whether containers share a process namespace"},
+	{Path: "pod.fs_gid", Kube: []string{"spec.securityContext.fsGroup"}, Doc: "the group that owns the pod's volumes"},
+	{Path: "pod.gids", Kube: []string{"spec.securityContext.supplementalGroups"}, Doc: "extra groups of the containers' processes"},
+	{Path: "pod.selinux", Kube: []string{"spec.securityContext.seLinuxOptions"}, Doc: "the SELinux context of the containers"},
+	{Path: "pod.uid", Kube: []string{"spec.securityContext.runAsUser"}, Doc: "the user the containers run as"},
+	{Path: "pod.gid", Kube: []string{"spec.securityContext.runAsGroup"}, Doc: "the group the containers run as"},
+	{Path: "pod.force_non_root", Kube: []string{"spec.securityContext.runAsNonRoot"}, Doc: "whether containers must run as a non-root user"},
+	{Path: "pod.sysctls", Kube: []string{"spec.securityContext.sysctls"}, Doc: "namespaced kernel parameters, as name=value"},
+	{Path: "pod.seccomp", Kube: []string{"metadata.annotations[seccomp.security.alpha.kubernetes.io/pod]"}, Doc: "the pod's seccomp profile"},
+	{Path: "pod.apparmor", Kube: []string{"metadata.annotations[container.apparmor.security.beta.kubernetes.io/*]"}, Doc: "the default AppArmor profile of the containers"},
+	{Path: "pod.security_profile", Kube: nil, Doc: "the default security profile of the containers, expanded into their security settings"},
+	{Path: "pod.registry_secrets", Kube: []string{"spec.imagePullSecrets"}, Doc: "secrets for pulling images"},
+	{Path: "pod.hostname", Kube: []string{"spec.hostname", "spec.subdomain"}, Doc: "the hostname, and subdomain after the first dot"},
+	{Path: "pod.affinity", Kube: []string{"spec.affinity"}, Doc: "node and pod (anti-)affinity rules"},
+	{Path: "pod.scheduler_name", Kube: []string{"spec.schedulerName"}, Doc: "the scheduler that places the pod", Default: "default-scheduler, left out when converting from kubernetes"},
+	{Path: "pod.tolerations", Kube: []string{"spec.tolerations"}, Doc: "taints the pod tolerates"},
+	{Path: "pod.host_aliases", Kube: []string{"spec.hostAliases"}, Doc: "entries added to the pod's hosts file"},
+	{Path: "pod.priorityClass", Kube: []string{"spec.priorityClassName"}, Doc: "the priority class of the pod"},
+	{Path: "pod.priority", Kube: []string{"spec.priority"}, Doc: "the priority of the pod"},
+	{Path: "pod.nameservers", Kube: []string{"spec.dnsConfig.nameservers"}, Doc: "DNS servers of the pod"},
+	{Path: "pod.searchDomains", Kube: []string{"spec.dnsConfig.searches"}, Doc: "DNS search domains of the pod"},
+	{Path: "pod.resolverOptions", Kube: []string{"spec.dnsConfig.options"}, Doc: "DNS resolver options of the pod"},
+	{Path: "pod.gates", Kube: []string{"spec.readinessGates"}, Doc: "extra conditions the pod must meet to be ready, e.g. target-health.elbv2.k8s.aws/pulsar-proxy"},
+	{Path: "pod.runtimeClass", Kube: []string{"spec.runtimeClassName"}, Doc: "the runtime class the pod runs with"},
+	{Path: "pod.serviceLinks", Kube: []string{"spec.enableServiceLinks"}, Doc: "whether service environment variables are injected", Default: "true, left out when converting from kubernetes"},
+	{Path: "pod.condition", Kube: []string{"status.conditions"}, Doc: "the conditions of the pod"},
+	{Path: "pod.node_ip", Kube: []string{"status.hostIP"}, Doc: "the IP of the pod's node"},
+	{Path: "pod.start_time", Kube: []string{"status.startTime"}, Doc: "when the pod was started"},
+	{Path: "pod.msg", Kube: []string{"status.message"}, Doc: "why the pod is in its phase"},
+	{Path: "pod.phase", Kube: []string{"status.phase"}, Doc: "the lifecycle phase of the pod"},
+	{Path: "pod.ip", Kube: []string{"status.podIP"}, Doc: "the IP of the pod"},
+	{Path: "pod.qos", Kube: []string{"status.qosClass"}, Doc: "the quality of service class of the pod"},
+	{Path: "pod.reason", Kube: []string{"status.reason"}, Doc: "a brief reason for the pod's phase"},
+}
+
+func containerFields(path, kube, status string) []Field {
+	security := kube + ".securityContext"
+	fs := []Field{
+		{Path: path + ".command", Kube: []string{kube + ".command"}, Doc: "the entrypoint of the container"},
+		{Path: path + ".args", Kube: []string{kube + ".args"}, Doc: "arguments to the entrypoint"},
+		{Path: path + ".env", Kube: []string{kube + ".env", kube + ".envFrom"}, Doc: "environment variables, set or read from a source"},
+		{Path: path + ".image", Kube: []string{kube + ".image"}, Doc: "the image the container runs"},
+		{Path: path + ".extra", Doc: "fields of the Container that mantle doesn't model, added to the generated container"},
+		{Path: path + ".pull", Kube: []string{kube + ".imagePullPolicy"}, Doc: "when the image is pulled", Default: "default, which is derived from the image's tag: always for latest or no tag, if-not-present otherwise"},
+		{Path: path + ".on_start", Kube: []string{kube + ".lifecycle.postStart"}, Doc: "an action run after the container starts"},
+		{Path: path + ".pre_stop", Kube: []string{kube + ".lifecycle.preStop"}, Doc: "an action run before the container stops"},
+		{Path: path + ".cpu", Kube: []string{kube + ".resources.requests.cpu", kube + ".resources.limits.cpu"}, Doc: "the CPU request (min) and limit (max)", Default: "no request or limit"},
+		{Path: path + ".mem", Kube: []string{kube + ".resources.requests.memory", kube + ".resources.limits.memory"}, Doc: "the memory request (min) and limit (max)", Default: "no request or limit"},
+		{Path: path + ".ephemeral_storage", Kube: []string{kube + ".resources.requests.ephemeral-storage", kube + ".resources.limits.ephemeral-storage"}, Doc: "the local storage request (min) and limit (max)", Default: "no request or limit"},
+		{Path: path + ".extended_resources", Kube: []string{kube + ".resources.requests", kube + ".resources.limits"}, Doc: "requests and limits of extended resources, e.g. GPUs"},
+		{Path: path + ".name", Kube: []string{kube + ".name"}, Doc: "the name of the container"},
+		{Path: path + ".cap_add", Kube: []string{security + ".capabilities.add"}, Doc: "capabilities added to the container"},
+		{Path: path + ".cap_drop", Kube: []string{security + ".capabilities.drop"}, Doc: "capabilities dropped from the container"},
+		{Path: path + ".privileged", Kube: []string{security + ".privileged"}, Doc: "whether the container runs privileged"},
+		{Path: path + ".allow_escalation", Kube: []string{security + ".allowPrivilegeEscalation"}, Doc: "whether processes may gain privileges"},
+		{Path: path + ".rw", Kube: []string{security + ".readOnlyRootFilesystem"}, Doc: "whether the root filesystem is writable", Default: "a writable root filesystem"},
+		{Path: path + ".ro", Kube: []string{security + ".readOnlyRootFilesystem"}, Doc: "whether the root filesystem is read-only", Default: "a writable root filesystem"},
+		{Path: path + ".force_non_root", Kube: []string{security + ".runAsNonRoot"}, Doc: "whether the container must run as a non-root user"},
+		{Path: path + ".uid", Kube: []string{security + ".runAsUser"}, Doc: "the user the container runs as"},
+		{Path: path + ".gid", Kube: []string{security + ".runAsGroup"}, Doc: "the group the container runs as"},
+		{Path: path + ".selinux", Kube: []string{security + ".seLinuxOptions"}, Doc: "the SELinux context of the container"},
+		{Path: path + ".seccomp", Kube: []string{"metadata.annotations[container.seccomp.security.alpha.kubernetes.io/<name>]"}, Doc: "the container's seccomp profile"},
+		{Path: path + ".apparmor", Kube: []string{"metadata.annotations[container.apparmor.security.beta.kubernetes.io/<name>]"}, Doc: "the container's AppArmor profile"},
+		{Path: path + ".security_profile", Kube: nil, Doc: "a security profile, expanded into the container's security settings"},
+		{Path: path + ".liveness_probe", Kube: []string{kube + ".livenessProbe"}, Doc: "restarts the container when it fails"},
+		{Path: path + ".readiness_probe", Kube: []string{kube + ".readinessProbe"}, Doc: "removes the pod from services when it fails"},
+		{Path: path + ".expose", Kube: []string{kube + ".ports"}, Doc: "ports the container listens on"},
+		{Path: path + ".stdin", Kube: []string{kube + ".stdin"}, Doc: "whether the container has a stdin"},
+		{Path: path + ".stdin_once", Kube: []string{kube + ".stdinOnce"}, Doc: "whether stdin is closed after the first attach"},
+		{Path: path + ".tty", Kube: []string{kube + ".tty"}, Doc: "whether the container has a TTY"},
+		{Path: path + ".wd", Kube: []string{kube + ".workingDir"}, Doc: "the working directory of the entrypoint"},
+		{Path: path + ".termination_msg_path", Kube: []string{kube + ".terminationMessagePath"}, Doc: "the file the termination message is read from", Default: "/dev/termination-log, left out when converting from kubernetes"},
+		{Path: path + ".termination_msg_policy", Kube: []string{kube + ".terminationMessagePolicy"}, Doc: "where the termination message is read from", Default: "file, the zero value"},
+		{Path: path + ".volume", Kube: []string{kube + ".volumeMounts"}, Doc: "volumes mounted into the container"},
+		{Path: path + ".volume.*.mount", Kube: []string{kube + ".volumeMounts[*].mountPath"}, Doc: "where the volume is mounted"},
+		{Path: path + ".volume.*.propagation", Kube: []string{kube + ".volumeMounts[*].mountPropagation"}, Doc: "how mounts are propagated", Default: "none, set by kubernetes"},
+		{Path: path + ".volume.*.store", Kube: []string{kube + ".volumeMounts[*].name", kube + ".volumeMounts[*].subPath"}, Doc: "the volume to mount, as name or name:subpath"},
+		{Path: path + ".volume.*.readOnly", Kube: []string{kube + ".volumeMounts[*].readOnly"}, Doc: "whether the volume is mounted read-only"},
+		{Path: path + ".container_id", Kube: []string{status + ".containerID"}, Doc: "the ID of the running container"},
+		{Path: path + ".image_id", Kube: []string{status + ".imageID"}, Doc: "the ID of the image the container runs"},
+		{Path: path + ".ready", Kube: []string{status + ".ready"}, Doc: "whether the container passes its readiness probe"},
+		{Path: path + ".last_state", Kube: []string{status + ".lastState"}, Doc: "the state of the container's last termination"},
+		{Path: path + ".current_state", Kube: []string{status + ".state"}, Doc: "the current state of the container"},
+		{Path: path + ".restarts", Kube: []string{status + ".restartCount"}, Doc: "how many times the container has restarted"},
+	}
+
+	for _, resource := range []struct{ name, kube string }{
+		{"cpu", "cpu"}, {"mem", "memory"}, {"ephemeral_storage", "ephemeral-storage"},
+	} {
+		fs = append(fs,
+			Field{Path: path + "." + resource.name + ".min", Kube: []string{kube + ".resources.requests." + resource.kube}, Doc: "the request", Default: "the max, set by kubernetes when only the max is given"},
+			Field{Path: path + "." + resource.name + ".max", Kube: []string{kube + ".resources.limits." + resource.kube}, Doc: "the limit", Default: "no limit"})
+	}
+	fs = append(fs,
+		Field{Path: path + ".extended_resources.*", Kube: []string{kube + ".resources.requests[*]", kube + ".resources.limits[*]"}, Doc: "the request (min) and limit (max) of an extended resource"},
+		Field{Path: path + ".extended_resources.*.min", Kube: []string{kube + ".resources.requests[*]"}, Doc: "the request"},
+		Field{Path: path + ".extended_resources.*.max", Kube: []string{kube + ".resources.limits[*]"}, Doc: "the limit"})
+
+	fs = append(fs, envFields(path+".env.*", kube)...)
+	fs = append(fs, portFields(path+".expose.*", kube+".ports[*]")...)
+	fs = append(fs, selinuxFields(path+".selinux", security+".seLinuxOptions")...)
+	fs = append(fs, actionFields(path+".on_start", kube+".lifecycle.postStart")...)
+	fs = append(fs, actionFields(path+".pre_stop", kube+".lifecycle.preStop")...)
+	fs = append(fs, probeFields(path+".liveness_probe", kube+".livenessProbe")...)
+	fs = append(fs, probeFields(path+".readiness_probe", kube+".readinessProbe")...)
+
+	return fs
+}
+
+var configMapFields = []Field{
+	{Path: "config_map.data", Kube: []string{"data"}, Doc: "UTF-8 configuration data, by key"},
+	{Path: "config_map.data.*", Kube: []string{"data[*]"}, Doc: "a configuration value"},
+	{Path: "config_map.binaryData", Kube: []string{"binaryData"}, Doc: "binary configuration data, by key"},
+	{Path: "config_map.binaryData.*", Kube: []string{"binaryData[*]"}, Doc: "a base64 encoded configuration value"},
+}
+
+// volumeTypes pairs the mantle volume sources with their kubernetes fields
+var volumeTypes = []struct{ name, kube string }{
+	{"HostPath", "hostPath"},
+	{"EmptyDir", "emptyDir"},
+	{"GcePD", "gcePersistentDisk"},
+	{"AwsEBS", "awsElasticBlockStore"},
+	{"AzureDisk", "azureDisk"},
+	{"AzureFile", "azureFile"},
+	{"CephFS", "cephfs"},
+	{"Cinder", "cinder"},
+	{"FibreChannel", "fc"},
+	{"Flex", "flexVolume"},
+	{"Flocker", "flocker"},
+	{"Glusterfs", "glusterfs"},
+	{"ISCSI", "iscsi"},
+	{"NFS", "nfs"},
+	{"PhotonPD", "photonPersistentDisk"},
+	{"Portworx", "portworxVolume"},
+	{"PVC", "persistentVolumeClaim"},
+	{"Quobyte", "quobyte"},
+	{"ScaleIO", "scaleIO"},
+	{"Vsphere", "vsphereVolume"},
+	{"ConfigMap", "configMap"},
+	{"Secret", "secret"},
+	{"DownwardAPI", "downwardAPI"},
+	{"Projected", "projected"},
+	{"Git", "gitRepo"},
+	{"RBD", "rbd"},
+	{"StorageOS", "storageos"},
+}
+
+func volumeFields() []Field {
+	var fs []Field
+	for _, t := range volumeTypes {
+		fs = append(fs, Field{
+			Path: "pod.volumes.*." + t.name,
+			Kube: []string{"spec.volumes[*]." + t.kube},
+			Doc:  "a " + t.kube + " volume source; set exactly one source per volume",
+		})
+	}
+	return fs
+}
+
+var tolerationFields = []Field{
+	{Path: "pod.tolerations.*.key", Kube: []string{"spec.tolerations[*].key"}, Doc: "the taint key, or empty for every key"},
+	{Path: "pod.tolerations.*.op", Kube: []string{"spec.tolerations[*].operator"}, Doc: "how the value is matched", Default: "exists, the zero value"},
+	{Path: "pod.tolerations.*.value", Kube: []string{"spec.tolerations[*].value"}, Doc: "the taint value, for the equal operator"},
+	{Path: "pod.tolerations.*.effect", Kube: []string{"spec.tolerations[*].effect"}, Doc: "the taint effect tolerated, or all for every effect", Default: "no-schedule, the zero value"},
+	{Path: "pod.tolerations.*.expirationSeconds", Kube: []string{"spec.tolerations[*].tolerationSeconds"}, Doc: "how long a no-execute taint is tolerated", Default: "forever"},
+}
+
+func selinuxFields(path, kube string) []Field {
+	return []Field{
+		{Path: path + ".user", Kube: []string{kube + ".user"}, Doc: "the SELinux user"},
+		{Path: path + ".role", Kube: []string{kube + ".role"}, Doc: "the SELinux role"},
+		{Path: path + ".type", Kube: []string{kube + ".type"}, Doc: "the SELinux type"},
+		{Path: path + ".level", Kube: []string{kube + ".level"}, Doc: "the SELinux level"},
+	}
+}
+
+func envFields(path, kube string) []Field {
+	return []Field{
+		{Path: path + ".Type", Doc: "whether the var is set from a value or read from a source"},
+		{Path: path + ".Val.Key", Kube: []string{kube + ".env[*].name"}, Doc: "the name of the var"},
+		{Path: path + ".Val.Val", Kube: []string{kube + ".env[*].value"}, Doc: "the value of the var"},
+		{Path: path + ".From.From", Kube: []string{kube + ".env[*].valueFrom", kube + ".envFrom[*]"}, Doc: "the source: config, secret, or a pod or resource field, e.g. metadata.name or limits.cpu"},
+		{Path: path + ".From.varNameOrPrefix", Kube: []string{kube + ".env[*].name", kube + ".envFrom[*].prefix"}, Doc: "the name of the var, or the prefix of every var when a whole ConfigMap or Secret is read"},
+		{Path: path + ".From.configMapOrSecretName", Kube: []string{kube + ".env[*].valueFrom.configMapKeyRef.name", kube + ".env[*].valueFrom.secretKeyRef.name", kube + ".envFrom[*].configMapRef.name", kube + ".envFrom[*].secretRef.name"}, Doc: "the ConfigMap or Secret to read"},
+		{Path: path + ".From.configMapOrSecretKey", Kube: []string{kube + ".env[*].valueFrom.configMapKeyRef.key", kube + ".env[*].valueFrom.secretKeyRef.key"}, Doc: "the key to read; leave it out to read every key"},
+		{Path: path + ".From.required", Kube: []string{kube + ".env[*].valueFrom.configMapKeyRef.optional", kube + ".env[*].valueFrom.secretKeyRef.optional"}, Doc: "whether the source must exist, the opposite of optional"},
+	}
+}
+
+func portFields(path, kube string) []Field {
+	return []Field{
+		{Path: path + ".Name", Kube: []string{kube + ".name"}, Doc: "the name of the port"},
+		{Path: path + ".Protocol", Kube: []string{kube + ".protocol"}, Doc: "the protocol of the port", Default: "tcp, the zero value"},
+		{Path: path + ".IP", Kube: []string{kube + ".hostIP"}, Doc: "the host IP to bind the host port to"},
+		{Path: path + ".HostPort", Kube: []string{kube + ".hostPort"}, Doc: "the port to expose on the host"},
+		{Path: path + ".ContainerPort", Kube: []string{kube + ".containerPort"}, Doc: "the port the container listens on"},
+	}
+}
+
+func actionFields(path, kube string) []Field {
+	return []Field{
+		{Path: path + ".exec", Kube: []string{kube + ".exec.command"}, Doc: "a command to run in the container"},
+		{Path: path + ".net", Kube: []string{kube + ".httpGet", kube + ".tcpSocket"}, Doc: "a URL to request or connect to"},
+		{Path: path + ".headers", Kube: []string{kube + ".httpGet.httpHeaders"}, Doc: "HTTP headers, as name:value"},
+	}
+}
+
+func probeFields(path, kube string) []Field {
+	return append(actionFields(path, kube),
+		Field{Path: path + ".delay", Kube: []string{kube + ".initialDelaySeconds"}, Doc: "seconds to wait before the first probe", Default: "0"},
+		Field{Path: path + ".interval", Kube: []string{kube + ".periodSeconds"}, Doc: "seconds between probes", Default: "10, left out when converting from kubernetes"},
+		Field{Path: path + ".timeout", Kube: []string{kube + ".timeoutSeconds"}, Doc: "seconds before a probe times out", Default: "1, left out when converting from kubernetes"},
+		Field{Path: path + ".min_count_success", Kube: []string{kube + ".successThreshold"}, Doc: "successes in a row to pass after failing", Default: "1, left out when converting from kubernetes"},
+		Field{Path: path + ".min_count_fail", Kube: []string{kube + ".failureThreshold"}, Doc: "failures in a row to fail", Default: "3, left out when converting from kubernetes"},
+	)
+}
@@ -0,0 +1,93 @@
+package fieldmap
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mantle/pkg/schema"
+)
+
+func testIndex(t *testing.T) *schema.Index {
+	s, err := schema.Generate()
+	if err != nil {
+		t.Fatalf("unexpected error generating schema: %v", err)
+	}
+	return schema.NewIndex(s)
+}
+
+// Every field in the table must be a field of the mantle types, so
+// the table can't drift from them unnoticed.
+func TestFieldsInSchema(t *testing.T) {
+	index := testIndex(t)
+	for _, field := range Fields() {
+		if index.At(strings.Split(field.Path, ".")) == nil {
+			t.Errorf("%s isn't in the mantle schema", field.Path)
+		}
+	}
+}
+
+func paths(fields []Field) []string {
+	var result []string
+	for _, field := range fields {
+		result = append(result, field.Path)
+	}
+	return result
+}
+
+func TestFind(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected []string
+	}{
+		{"pod.containers.cpu", []string{"pod.containers.*.cpu"}},
+		{"pod.containers.0.cpu", []string{"pod.containers.*.cpu"}},
+		{"cpu.min", []string{"pod.init_containers.*.cpu.min", "pod.containers.*.cpu.min"}},
+		{"volume.pvc", []string{"pod.volumes.*.PVC"}},
+		{"probe.delay", []string{
+			"pod.init_containers.*.liveness_probe.delay", "pod.init_containers.*.readiness_probe.delay",
+			"pod.containers.*.liveness_probe.delay", "pod.containers.*.readiness_probe.delay",
+		}},
+		{"config_map.data", []string{"config_map.data", "config_map.data.*"}},
+		{"pod.colour", nil},
+	}
+
+	for _, testCase := range testCases {
+		if result := paths(Find(testCase.query)); !reflect.DeepEqual(result, testCase.expected) {
+			t.Errorf("%s: expected %v, got %v", testCase.query, testCase.expected, result)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	field, ok := Lookup("pod", "containers", "0", "volume", "1", "store")
+	if !ok || field.Path != "pod.containers.*.volume.*.store" {
+		t.Errorf("expected the volume mount store field, got %+v", field)
+	}
+
+	if _, ok := Lookup("pod", "containers", "0", "colour"); ok {
+		t.Errorf("expected no field for an unknown path")
+	}
+}
+
+func TestExplain(t *testing.T) {
+	field, _ := Lookup("pod", "containers", "0", "cpu")
+
+	out := &bytes.Buffer{}
+	if err := Explain(out, *field, testIndex(t)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"type        string | number | object",
+		"shorthand   min-max",
+		"kubernetes  spec.containers[*].resources.requests.cpu",
+		"            spec.containers[*].resources.limits.cpu",
+		"fields      min, max",
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("expected %q in:\n%s", expected, out.String())
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/references"
+
+	"github.com/koki/json"
+
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Relations between objects, in addition to the
+// ways pods reference objects (see references.Reference)
+const (
+	RelationSelects  = "selects"
+	RelationScales   = "scales"
+	RelationProtects = "protects"
+)
+
+// orphanKinds are the kinds that are only useful when a pod uses them
+var orphanKinds = map[string]bool{
+	references.KindConfigMap: true,
+	references.KindSecret:    true,
+	references.KindPVC:       true,
+}
+
+// Node is an object in the graph. Missing nodes are referenced
+// by objects in the bundle but aren't in the bundle themselves.
+type Node struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Location  string `json:"location,omitempty"`
+	Missing   bool   `json:"missing,omitempty"`
+}
+
+// Edge points from an object to an object it depends on or acts on
+type Edge struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+}
+
+// Graph holds the objects of a bundle and the relations between them
+type Graph struct {
+	Nodes []Node `json:"nodes"`
+	Edges []Edge `json:"edges"`
+
+	nodes map[string]int
+	edges map[Edge]bool
+}
+
+// Build creates the graph of a bundle's objects
+func Build(b *bundle.Bundle) (*Graph, error) {
+	g := &Graph{nodes: map[string]int{}, edges: map[Edge]bool{}}
+	for _, object := range b.Objects {
+		g.addNode(nodeFor(object))
+	}
+
+	templates, err := b.PodTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	index := references.NewIndex(b)
+	for _, template := range templates {
+		namespace := template.Pod.Namespace
+		if len(namespace) == 0 {
+			namespace = template.Object.Namespace
+		}
+
+		for _, r := range references.PodReferences(template.Pod) {
+			to := index.Lookup(r.Kind, namespace, r.Name)
+			if to == nil {
+				to = &bundle.Object{Kind: r.Kind, Name: r.Name, Namespace: namespace}
+				if r.Kind == references.KindPriorityClass {
+					to.Namespace = ""
+				}
+				node := nodeFor(to)
+				node.Missing = true
+				g.addNode(node)
+			}
+			g.addEdge(template.Object.ID(), to.ID(), r.Via)
+		}
+	}
+
+	for _, object := range b.Objects {
+		switch obj := object.Kube.(type) {
+		case *v1.Service:
+			if len(obj.Spec.Selector) == 0 {
+				continue
+			}
+			selector := labels.SelectorFromSet(obj.Spec.Selector)
+			g.selectTemplates(object, templates, selector, RelationSelects)
+		case *policyv1beta1.PodDisruptionBudget:
+			selector, err := metav1.LabelSelectorAsSelector(obj.Spec.Selector)
+			if err != nil || selector.Empty() {
+				continue
+			}
+			g.selectTemplates(object, templates, selector, RelationProtects)
+		case *autoscalingv1.HorizontalPodAutoscaler:
+			g.scaleTarget(object, index, obj.Spec.ScaleTargetRef.Kind, obj.Spec.ScaleTargetRef.Name)
+		case *autoscalingv2beta1.HorizontalPodAutoscaler:
+			g.scaleTarget(object, index, obj.Spec.ScaleTargetRef.Kind, obj.Spec.ScaleTargetRef.Name)
+		case *autoscalingv2beta2.HorizontalPodAutoscaler:
+			g.scaleTarget(object, index, obj.Spec.ScaleTargetRef.Kind, obj.Spec.ScaleTargetRef.Name)
+		}
+	}
+
+	return g, nil
+}
+
+func nodeFor(object *bundle.Object) Node {
+	node := Node{
+		ID:        object.ID(),
+		Kind:      object.Kind,
+		Name:      object.Name,
+		Namespace: object.Namespace,
+	}
+	if len(object.Source) > 0 {
+		node.Location = object.Location()
+	}
+	return node
+}
+
+func (g *Graph) addNode(node Node) {
+	if _, ok := g.nodes[node.ID]; ok {
+		return
+	}
+	g.nodes[node.ID] = len(g.Nodes)
+	g.Nodes = append(g.Nodes, node)
+}
+
+func (g *Graph) addEdge(from, to, relation string) {
+	edge := Edge{From: from, To: to, Relation: relation}
+	if g.edges[edge] {
+		return
+	}
+	g.edges[edge] = true
+	g.Edges = append(g.Edges, edge)
+}
+
+// selectTemplates adds an edge from object to each pod or workload in its
+// namespace whose pod template labels match the selector
+func (g *Graph) selectTemplates(object *bundle.Object, templates []bundle.PodTemplate, selector labels.Selector, relation string) {
+	for _, template := range templates {
+		if !sameNamespace(object.Namespace, template.Object.Namespace) {
+			continue
+		}
+		if selector.Matches(labels.Set(template.Pod.Labels)) {
+			g.addEdge(object.ID(), template.Object.ID(), relation)
+		}
+	}
+}
+
+func (g *Graph) scaleTarget(object *bundle.Object, index references.Index, kind, name string) {
+	if target := index.Lookup(kind, object.Namespace, name); target != nil {
+		g.addEdge(object.ID(), target.ID(), RelationScales)
+	}
+}
+
+func sameNamespace(a, b string) bool {
+	if len(a) == 0 {
+		a = metav1.NamespaceDefault
+	}
+	if len(b) == 0 {
+		b = metav1.NamespaceDefault
+	}
+	return a == b
+}
+
+// Orphans returns the ConfigMaps, Secrets and PersistentVolumeClaims
+// in the bundle that no other object in the bundle uses
+func (g *Graph) Orphans() []Node {
+	used := map[string]bool{}
+	for _, edge := range g.Edges {
+		used[edge.To] = true
+	}
+
+	var orphans []Node
+	for _, node := range g.Nodes {
+		if orphanKinds[node.Kind] && !node.Missing && !used[node.ID] {
+			orphans = append(orphans, node)
+		}
+	}
+	return orphans
+}
+
+// Output formats for graphs
+const (
+	FormatDOT  = "dot"
+	FormatJSON = "json"
+)
+
+// Write writes the graph to w in the given format
+func (g *Graph) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatDOT, "":
+		return g.WriteDOT(w)
+	case FormatJSON:
+		return g.WriteJSON(w)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// WriteJSON writes the graph as {"nodes": [...], "edges": [...]}
+func (g *Graph) WriteJSON(w io.Writer) error {
+	out := *g
+	if out.Nodes == nil {
+		out.Nodes = []Node{}
+	}
+	if out.Edges == nil {
+		out.Edges = []Edge{}
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// WriteDOT writes the graph in graphviz's DOT language
+func (g *Graph) WriteDOT(w io.Writer) error {
+	lines := []string{"digraph mantle {", "  rankdir=LR;"}
+
+	for _, node := range g.Nodes {
+		attrs := []string{fmt.Sprintf("shape=%s", shape(node.Kind))}
+		if node.Missing {
+			attrs = append(attrs, "style=dashed")
+		}
+		lines = append(lines, fmt.Sprintf("  %s [%s];", quote(node.ID), strings.Join(attrs, ", ")))
+	}
+
+	edges := append([]Edge{}, g.Edges...)
+	sort.SliceStable(edges, func(i, j int) bool {
+		return edges[i].From < edges[j].From
+	})
+	for _, edge := range edges {
+		lines = append(lines, fmt.Sprintf("  %s -> %s [label=%s];", quote(edge.From), quote(edge.To), quote(edge.Relation)))
+	}
+
+	lines = append(lines, "}")
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+func shape(kind string) string {
+	switch kind {
+	case references.KindConfigMap, references.KindSecret:
+		return "note"
+	case references.KindPVC:
+		return "cylinder"
+	case "Service":
+		return "ellipse"
+	case "HorizontalPodAutoscaler", "PodDisruptionBudget":
+		return "diamond"
+	default:
+		return "box"
+	}
+}
+
+func quote(s string) string {
+	return `"` + strings.Replace(strings.Replace(s, `\`, `\\`, -1), `"`, `\"`, -1) + `"`
+}
@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mantle/pkg/bundle"
+)
+
+const testBundle = `
+config_map:
+  name: broker-config
+  data:
+    PULSAR_MEM: 2g
+---
+config_map:
+  name: unused
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: broker
+spec:
+  template:
+    metadata:
+      labels:
+        app: broker
+    spec:
+      containers:
+      - name: broker
+        image: apachepulsar/pulsar:2.1
+        env:
+        - name: PULSAR_MEM
+          valueFrom:
+            configMapKeyRef:
+              name: broker-config
+              key: PULSAR_MEM
+      volumes:
+      - name: certs
+        secret:
+          secretName: tls
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: broker
+spec:
+  selector:
+    app: broker
+---
+apiVersion: policy/v1beta1
+kind: PodDisruptionBudget
+metadata:
+  name: broker
+spec:
+  selector:
+    matchLabels:
+      app: broker
+---
+apiVersion: autoscaling/v1
+kind: HorizontalPodAutoscaler
+metadata:
+  name: broker
+spec:
+  scaleTargetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: broker
+  maxReplicas: 3
+`
+
+func buildTestGraph(t *testing.T) *Graph {
+	objects, err := bundle.Read("bundle.yaml", strings.NewReader(testBundle))
+	if err != nil {
+		t.Fatalf("unexpected error reading bundle: %v", err)
+	}
+
+	g, err := Build(&bundle.Bundle{Objects: objects})
+	if err != nil {
+		t.Fatalf("unexpected error building graph: %v", err)
+	}
+	return g
+}
+
+func TestBuild(t *testing.T) {
+	g := buildTestGraph(t)
+
+	var edges []string
+	for _, edge := range g.Edges {
+		edges = append(edges, edge.From+" -"+edge.Relation+"-> "+edge.To)
+	}
+
+	expected := []string{
+		"Deployment/broker -env-> ConfigMap/broker-config",
+		"Deployment/broker -volume-> Secret/tls",
+		"Service/broker -selects-> Deployment/broker",
+		"PodDisruptionBudget/broker -protects-> Deployment/broker",
+		"HorizontalPodAutoscaler/broker -scales-> Deployment/broker",
+	}
+	if !reflect.DeepEqual(edges, expected) {
+		t.Errorf("expected edges\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(edges, "\n"))
+	}
+
+	orphans := g.Orphans()
+	if len(orphans) != 1 || orphans[0].ID != "ConfigMap/unused" {
+		t.Errorf("expected ConfigMap/unused to be the only orphan, got %v", orphans)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := buildTestGraph(t)
+
+	dot := &bytes.Buffer{}
+	if err := g.Write(dot, FormatDOT); err != nil {
+		t.Fatalf("unexpected error writing DOT: %v", err)
+	}
+	for _, line := range []string{
+		`"Secret/tls" [shape=note, style=dashed];`,
+		`"Service/broker" -> "Deployment/broker" [label="selects"];`,
+	} {
+		if !strings.Contains(dot.String(), line) {
+			t.Errorf("expected DOT output to contain %s, got\n%s", line, dot)
+		}
+	}
+
+	js := &bytes.Buffer{}
+	if err := g.Write(js, FormatJSON); err != nil {
+		t.Fatalf("unexpected error writing JSON: %v", err)
+	}
+	if !strings.Contains(js.String(), `"relation": "scales"`) {
+		t.Errorf("expected JSON output to contain the scales edge, got\n%s", js)
+	}
+}
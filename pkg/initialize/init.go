@@ -7,8 +7,8 @@ import (
 	"mantle/pkg/codec"
 )
 
-func MantleInit() error {
-	out, err := codec.Decode(os.Stdin)
+func MantleInit(opts codec.DecodeOptions) error {
+	out, err := codec.Decode(os.Stdin, opts)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,175 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/report"
+
+	serrors "github.com/koki/structurederrors"
+)
+
+// DisableAnnotation lists the rules to skip for a pod, separated by
+// commas, e.g. "latest-image,host-path". "all" disables every rule.
+const DisableAnnotation = "mantle.io/lint-disable"
+
+const disableAll = "all"
+
+// Problem is a single problem found by a rule. Path is the mantle
+// field path of the problem within the pod, e.g. containers[0].cpu
+type Problem struct {
+	Path    string
+	Message string
+}
+
+// Rule is a best-practice check that runs over a decoded pod
+type Rule interface {
+	// Name identifies the rule in findings and in DisableAnnotation
+	Name() string
+	Severity() report.Severity
+	Description() string
+	Check(p *pod.Pod) []Problem
+}
+
+type rule struct {
+	name        string
+	severity    report.Severity
+	description string
+	check       func(p *pod.Pod) []Problem
+}
+
+// NewRule creates a rule from a check function
+func NewRule(name string, severity report.Severity, description string, check func(p *pod.Pod) []Problem) Rule {
+	return &rule{
+		name:        name,
+		severity:    severity,
+		description: description,
+		check:       check,
+	}
+}
+
+func (r *rule) Name() string {
+	return r.name
+}
+
+func (r *rule) Severity() report.Severity {
+	return r.severity
+}
+
+func (r *rule) Description() string {
+	return r.description
+}
+
+func (r *rule) Check(p *pod.Pod) []Problem {
+	return r.check(p)
+}
+
+// Linter runs a set of rules
+type Linter struct {
+	Rules []Rule
+}
+
+// NewLinter creates a linter with the built-in rules
+func NewLinter() *Linter {
+	return &Linter{Rules: BuiltinRules()}
+}
+
+// Register adds a rule to the linter
+func (l *Linter) Register(rule Rule) {
+	l.Rules = append(l.Rules, rule)
+}
+
+// Disable removes the named rules from the linter. It's an error
+// to name a rule the linter doesn't have.
+func (l *Linter) Disable(names ...string) error {
+	disabled := map[string]bool{}
+	for _, name := range names {
+		if l.rule(name) == nil {
+			return serrors.InvalidValueErrorf(name, "unknown lint rule")
+		}
+		disabled[name] = true
+	}
+
+	var rules []Rule
+	for _, rule := range l.Rules {
+		if !disabled[rule.Name()] {
+			rules = append(rules, rule)
+		}
+	}
+	l.Rules = rules
+
+	return nil
+}
+
+func (l *Linter) rule(name string) Rule {
+	for _, rule := range l.Rules {
+		if rule.Name() == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+// Bundle lints the pod template of every pod and workload in the bundle
+func (l *Linter) Bundle(b *bundle.Bundle) ([]report.Finding, error) {
+	templates, err := b.PodTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []report.Finding
+	for _, template := range templates {
+		for _, finding := range l.Pod(template.Pod) {
+			finding.Object = template.Object.ID()
+			finding.Location = template.Object.Location()
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, nil
+}
+
+// Pod lints a pod, skipping the rules disabled by its annotations
+func (l *Linter) Pod(p *pod.Pod) []report.Finding {
+	disabled := disabledRules(p)
+	if disabled[disableAll] {
+		return nil
+	}
+
+	var findings []report.Finding
+	for _, rule := range l.Rules {
+		if disabled[rule.Name()] {
+			continue
+		}
+
+		for _, problem := range rule.Check(p) {
+			findings = append(findings, report.Finding{
+				Rule:     rule.Name(),
+				Severity: rule.Severity(),
+				Path:     problem.Path,
+				Message:  problem.Message,
+			})
+		}
+	}
+
+	return findings
+}
+
+func disabledRules(p *pod.Pod) map[string]bool {
+	disabled := map[string]bool{}
+	for _, name := range strings.Split(p.Annotations[DisableAnnotation], ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			disabled[name] = true
+		}
+	}
+	return disabled
+}
+
+// problems collects the problems found by a check
+type problems []Problem
+
+func (ps *problems) add(path, msgFormat string, args ...interface{}) {
+	*ps = append(*ps, Problem{Path: path, Message: fmt.Sprintf(msgFormat, args...)})
+}
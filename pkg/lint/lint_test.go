@@ -0,0 +1,137 @@
+package lint
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/report"
+)
+
+const testBundle = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+  namespace: kube-system
+spec:
+  template:
+    metadata:
+      namespace: kube-system
+    spec:
+      containers:
+      - name: agent
+        image: registry:5000/agent
+        imagePullPolicy: IfNotPresent
+        ports:
+        - containerPort: 9100
+        resources:
+          limits:
+            cpu: 100m
+            memory: 64Mi
+      volumes:
+      - name: proc
+        hostPath:
+          path: /proc
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: proxy
+  namespace: kube-system
+spec:
+  template:
+    spec:
+      containers:
+      - name: proxy
+        image: registry:5000/proxy:1.0
+        resources:
+          limits:
+            cpu: 100m
+            memory: 64Mi
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: broker
+  annotations:
+    mantle.io/lint-disable: resource-limits, readiness-probe
+spec:
+  containers:
+  - name: broker
+    image: apachepulsar/pulsar@sha256:0123
+    imagePullPolicy: Always
+    ports:
+    - containerPort: 8080
+  volumes:
+  - name: config
+    gitRepo:
+      repository: https://example.com/config.git
+`
+
+func lintTestBundle(t *testing.T, linter *Linter) []string {
+	objects, err := bundle.Read("bundle.yaml", strings.NewReader(testBundle))
+	if err != nil {
+		t.Fatalf("unexpected error reading bundle: %v", err)
+	}
+
+	findings, err := linter.Bundle(&bundle.Bundle{Objects: objects})
+	if err != nil {
+		t.Fatalf("unexpected error linting bundle: %v", err)
+	}
+
+	var results []string
+	for _, finding := range findings {
+		results = append(results, finding.Object+" "+finding.Path+" "+finding.Rule)
+	}
+	return results
+}
+
+func TestLintBuiltinRules(t *testing.T) {
+	expected := []string{
+		"DaemonSet/kube-system/agent containers[0].image latest-image",
+		"DaemonSet/kube-system/agent containers[0].readiness_probe readiness-probe",
+		"DaemonSet/kube-system/agent volumes.proc host-path",
+		"DaemonSet/kube-system/agent priorityClass system-priority-class",
+		"DaemonSet/kube-system/proxy priorityClass system-priority-class",
+		"Pod/broker containers[0].pull pull-always-digest",
+		"Pod/broker volumes.config deprecated-volume",
+	}
+
+	if results := lintTestBundle(t, NewLinter()); !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected findings %v, got %v", expected, results)
+	}
+}
+
+func TestLintRegisterAndDisable(t *testing.T) {
+	linter := &Linter{}
+	linter.Register(NewRule("named", report.SeverityError, "pods should have names", func(p *pod.Pod) []Problem {
+		if len(p.Name) == 0 {
+			return []Problem{{Path: "name", Message: "pod has no name"}}
+		}
+		return nil
+	}))
+	linter.Register(NewRule("never", report.SeverityError, "always fails", func(p *pod.Pod) []Problem {
+		return []Problem{{Message: "failed"}}
+	}))
+
+	if err := linter.Disable("never"); err != nil {
+		t.Fatalf("unexpected error disabling rule: %v", err)
+	}
+	if err := linter.Disable("unknown"); err == nil {
+		t.Errorf("expected an error disabling an unknown rule")
+	}
+
+	findings := linter.Pod(&pod.Pod{})
+	if len(findings) != 1 || findings[0].Rule != "named" || findings[0].Severity != report.SeverityError {
+		t.Errorf("unexpected findings %v", findings)
+	}
+
+	p := &pod.Pod{}
+	p.Annotations = map[string]string{DisableAnnotation: "all"}
+	if findings := linter.Pod(p); len(findings) > 0 {
+		t.Errorf("expected all rules to be disabled, got %v", findings)
+	}
+}
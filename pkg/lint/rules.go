@@ -0,0 +1,138 @@
+package lint
+
+import (
+	"fmt"
+
+	"mantle/pkg/core/pod"
+	"mantle/pkg/core/pod/container"
+	"mantle/pkg/core/pod/volume"
+	"mantle/pkg/report"
+)
+
+// systemNamespace holds the pods that keep the cluster running
+const systemNamespace = "kube-system"
+
+// BuiltinRules returns the rules that ship with mantle
+func BuiltinRules() []Rule {
+	return []Rule{
+		NewRule("resource-limits", report.SeverityWarning,
+			"containers should set cpu and mem limits", checkResourceLimits),
+		NewRule("latest-image", report.SeverityWarning,
+			"images should be pinned to a tag other than latest, or to a digest", checkLatestImage),
+		NewRule("pull-always-digest", report.SeverityInfo,
+			"images pinned to a digest don't need to be pulled every time", checkPullAlwaysDigest),
+		NewRule("readiness-probe", report.SeverityWarning,
+			"containers that expose ports should have a readiness probe", checkReadinessProbe),
+		NewRule("host-path", report.SeverityWarning,
+			"hostPath volumes tie pods to the node they run on", checkHostPath),
+		NewRule("system-priority-class", report.SeverityWarning,
+			fmt.Sprintf("pods in %s should set a priority class", systemNamespace), checkSystemPriorityClass),
+		NewRule("deprecated-volume", report.SeverityWarning,
+			"volume plugins deprecated by kubernetes shouldn't be used", checkDeprecatedVolumes),
+	}
+}
+
+func checkResourceLimits(p *pod.Pod) []Problem {
+	var ps problems
+	p.EachContainer(func(c *container.Container, path string) {
+		if c.CPU == nil || c.CPU.Max == nil {
+			ps.add(path+".cpu", "container (%s) has no cpu limit", c.Name)
+		}
+		if c.Mem == nil || c.Mem.Max == nil {
+			ps.add(path+".mem", "container (%s) has no mem limit", c.Name)
+		}
+	})
+	return ps
+}
+
+func checkLatestImage(p *pod.Pod) []Problem {
+	var ps problems
+	p.EachContainer(func(c *container.Container, path string) {
+		if len(c.Image) == 0 {
+			return
+		}
+
+		tag, digest := container.SplitImage(c.Image)
+		switch {
+		case len(digest) > 0:
+		case len(tag) == 0:
+			ps.add(path+".image", "image (%s) has no tag, so it uses latest", c.Image)
+		case tag == "latest":
+			ps.add(path+".image", "image (%s) uses the latest tag", c.Image)
+		}
+	})
+	return ps
+}
+
+func checkPullAlwaysDigest(p *pod.Pod) []Problem {
+	var ps problems
+	p.EachContainer(func(c *container.Container, path string) {
+		if _, digest := container.SplitImage(c.Image); len(digest) > 0 && c.Pull == container.PullAlways {
+			ps.add(path+".pull", "image (%s) is pinned to a digest, so it doesn't need to be pulled every time", c.Image)
+		}
+	})
+	return ps
+}
+
+func checkReadinessProbe(p *pod.Pod) []Problem {
+	var ps problems
+	// Init containers run to completion before the pod is ready,
+	// so only app containers need readiness probes.
+	for i, c := range p.Containers {
+		if len(c.Expose) > 0 && c.ReadinessProbe == nil {
+			ps.add(fmt.Sprintf("containers[%d].readiness_probe", i), "container (%s) exposes ports but has no readiness probe", c.Name)
+		}
+	}
+	return ps
+}
+
+func checkHostPath(p *pod.Pod) []Problem {
+	var ps problems
+	for _, name := range p.VolumeNames() {
+		if p.Volumes[name].HostPath != nil {
+			ps.add(fmt.Sprintf("volumes.%s", name), "volume (%s) is a hostPath volume", name)
+		}
+	}
+	return ps
+}
+
+func checkSystemPriorityClass(p *pod.Pod) []Problem {
+	var ps problems
+	if p.Namespace == systemNamespace && len(p.PriorityClass) == 0 {
+		ps.add("priorityClass", "pods in %s should set a priority class so they aren't evicted first", systemNamespace)
+	}
+	return ps
+}
+
+// deprecatedVolume returns the name of the volume's plugin
+// if kubernetes has deprecated it
+func deprecatedVolume(v volume.Volume) (string, bool) {
+	switch {
+	case v.Git != nil:
+		return "gitRepo", true
+	case v.Flocker != nil:
+		return "flocker", true
+	case v.PhotonPD != nil:
+		return "photonPersistentDisk", true
+	case v.Quobyte != nil:
+		return "quobyte", true
+	case v.ScaleIO != nil:
+		return "scaleIO", true
+	case v.StorageOS != nil:
+		return "storageos", true
+	case v.Glusterfs != nil:
+		return "glusterfs", true
+	default:
+		return "", false
+	}
+}
+
+func checkDeprecatedVolumes(p *pod.Pod) []Problem {
+	var ps problems
+	for _, name := range p.VolumeNames() {
+		if plugin, ok := deprecatedVolume(p.Volumes[name]); ok {
+			ps.add(fmt.Sprintf("volumes.%s", name), "volume (%s) uses the deprecated %s plugin", name, plugin)
+		}
+	}
+	return ps
+}
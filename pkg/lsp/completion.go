@@ -0,0 +1,56 @@
+package lsp
+
+import (
+	"strings"
+
+	"mantle/pkg/fieldmap"
+)
+
+// completion suggests the field names of the mapping the cursor is in,
+// or the allowed values of the field whose value the cursor is on.
+func (srv *Server) completion(d *document, pos Position) CompletionList {
+	list := CompletionList{Items: []CompletionItem{}}
+
+	c := d.cursorAt(pos)
+	if c == nil {
+		return list
+	}
+
+	schemas := srv.index.At(c.path)
+	if c.inValue {
+		for _, value := range srv.index.Values(schemas) {
+			if strings.HasPrefix(value, c.prefix) {
+				list.Items = append(list.Items, CompletionItem{
+					Label: value,
+					Kind:  CompletionKindEnumValue,
+				})
+			}
+		}
+		return list
+	}
+
+	for _, name := range srv.index.Properties(schemas) {
+		if !strings.HasPrefix(name, c.prefix) {
+			continue
+		}
+
+		// Skip fields the mapping already has, except the one being typed.
+		path := appendPath(c.path, name)
+		if _, ok := c.section.keys[strings.Join(path, ".")]; ok && name != c.key {
+			continue
+		}
+
+		item := CompletionItem{
+			Label:      name,
+			Kind:       CompletionKindField,
+			Detail:     srv.index.Describe(srv.index.At(path)),
+			InsertText: name + ": ",
+		}
+		if field, ok := fieldmap.Lookup(path...); ok {
+			item.Documentation = field.Doc
+		}
+		list.Items = append(list.Items, item)
+	}
+
+	return list
+}
@@ -0,0 +1,118 @@
+package lsp
+
+import (
+	"sort"
+	"strings"
+
+	"mantle/pkg/codec"
+	"mantle/pkg/core/pod/container/env"
+)
+
+// definition finds what the value on the cursor's line refers to: the
+// volume of a volume mount's store, or the ConfigMap or Secret of an
+// env var. ConfigMaps and Secrets are searched for in every open document.
+func (srv *Server) definition(d *document, pos Position) []Location {
+	locations := []Location{}
+
+	c := d.cursorAt(pos)
+	if c == nil || len(c.keyPath) < 2 || len(c.value) == 0 {
+		return locations
+	}
+
+	s := c.section
+	parent := c.keyPath[:len(c.keyPath)-1]
+	switch c.key {
+	case "store":
+		if len(parent) < 2 || parent[len(parent)-2] != "volume" {
+			return locations
+		}
+		name := strings.SplitN(c.value, ":", 2)[0]
+		if pos, ok := s.keys[strings.Join([]string{s.kind, "volumes", name}, ".")]; ok {
+			locations = append(locations, Location{URI: d.uri, Range: d.keyRange(s.filePosition(pos))})
+		}
+
+	case "configMapOrSecretName":
+		from, ok := s.keys[strings.Join(appendPath(parent, "From"), ".")]
+		if !ok {
+			return locations
+		}
+		switch env.EnvFromType(d.valueAt(s.filePosition(from))) {
+		case env.EnvFromTypeConfig:
+			locations = srv.findObject("ConfigMap", c.value)
+		case env.EnvFromTypeSecret:
+			locations = srv.findObject("Secret", c.value)
+		}
+	}
+
+	return locations
+}
+
+// findObject returns where the object of the given kubernetes kind
+// and name is defined, either as a mantle or a kubernetes document
+func (srv *Server) findObject(kind, name string) []Location {
+	locations := []Location{}
+
+	uris := make([]string, 0, len(srv.docs))
+	for uri := range srv.docs {
+		uris = append(uris, uri)
+	}
+	sort.Strings(uris)
+
+	for _, uri := range uris {
+		d := srv.docs[uri]
+		for _, s := range d.sections {
+			if path, ok := objectName(s, kind, name); ok {
+				if pos, ok := s.lookup(path); ok {
+					locations = append(locations, Location{URI: uri, Range: d.keyRange(pos)})
+				}
+			}
+		}
+	}
+
+	return locations
+}
+
+// objectName returns the path of the name of the section's object, if
+// it's the object of the given kind and name
+func objectName(s *section, kind, name string) (string, bool) {
+	if s.obj == nil {
+		return "", false
+	}
+
+	if len(s.kind) > 0 {
+		if kind != "ConfigMap" || s.kind != codec.KindConfigMap {
+			return "", false
+		}
+		body, _ := s.obj[s.kind].(map[string]interface{})
+		return s.kind + ".name", body["name"] == name
+	}
+
+	metadata, _ := s.obj["metadata"].(map[string]interface{})
+	return "metadata.name", s.obj["kind"] == kind && metadata["name"] == name
+}
+
+// keyRange returns the range of the key at pos
+func (d *document) keyRange(pos Position) Range {
+	text := d.line(pos.Line)
+	end := pos.Character
+	if end < len(text) {
+		if colon := keyColon(text[end:]); colon >= 0 {
+			end += colon
+		}
+	}
+	return Range{Start: pos, End: Position{Line: pos.Line, Character: end}}
+}
+
+// valueAt returns the value of the key at pos
+func (d *document) valueAt(pos Position) string {
+	text := d.line(pos.Line)
+	if pos.Character >= len(text) {
+		return ""
+	}
+
+	colon := keyColon(text[pos.Character:])
+	if colon < 0 {
+		return ""
+	}
+	return unquote(stripComment(strings.TrimSpace(text[pos.Character+colon+1:])))
+}
@@ -0,0 +1,85 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/internal/yaml"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// diagnosticSource names the server in the editor's problem list
+const diagnosticSource = "mantle"
+
+// validator is implemented by mantle objects that check themselves
+type validator interface {
+	Validate() field.ErrorList
+}
+
+// diagnostics returns the problems with every section of the document:
+// decoding errors, validation errors, and fields the schema doesn't know.
+func (srv *Server) diagnostics(d *document) []Diagnostic {
+	diagnostics := []Diagnostic{}
+
+	for _, s := range d.sections {
+		if s.err != nil {
+			pos, msg := Position{Line: s.Line - 1}, s.err.Error()
+			if located, ok := yaml.Locate(s.err, s.positions); ok {
+				pos, msg = s.filePosition(located.Position), located.Err.Error()
+			}
+			diagnostics = append(diagnostics, d.diagnostic(pos, SeverityError, msg))
+		}
+
+		if len(s.kind) == 0 {
+			continue
+		}
+
+		diagnostics = append(diagnostics, srv.unknownFields(d, s)...)
+
+		if v, ok := s.mantle.(validator); ok {
+			for _, err := range v.Validate() {
+				pos, ok := s.lookup(s.kind + "." + err.Field)
+				if !ok {
+					pos = Position{Line: s.Line - 1}
+				}
+				diagnostics = append(diagnostics, d.diagnostic(pos, SeverityError, err.Error()))
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+// unknownFields warns about keys that aren't in the schema, which
+// decoding silently drops
+func (srv *Server) unknownFields(d *document, s *section) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, e := range s.entries {
+		if len(e.path) < 2 || isIndex(e.path[len(e.path)-1]) {
+			continue
+		}
+		if srv.index.At(e.path) != nil || srv.index.At(e.path[:len(e.path)-1]) == nil {
+			continue
+		}
+
+		msg := fmt.Sprintf("unknown field %q in %s", e.path[len(e.path)-1], strings.Join(e.path[:len(e.path)-1], "."))
+		diagnostics = append(diagnostics, d.diagnostic(s.filePosition(e.Position), SeverityWarning, msg))
+	}
+	return diagnostics
+}
+
+// diagnostic reports a problem from pos to the end of its line
+func (d *document) diagnostic(pos Position, severity DiagnosticSeverity, msg string) Diagnostic {
+	end := Position{Line: pos.Line, Character: len(strings.TrimRight(d.line(pos.Line), " \t"))}
+	if end.Character < pos.Character {
+		end.Character = pos.Character
+	}
+
+	return Diagnostic{
+		Range:    Range{Start: pos, End: end},
+		Severity: severity,
+		Source:   diagnosticSource,
+		Message:  msg,
+	}
+}
@@ -0,0 +1,295 @@
+package lsp
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"mantle/internal/yaml"
+	"mantle/pkg/codec"
+)
+
+// document is a file open in the editor
+type document struct {
+	uri      string
+	lines    []string
+	sections []*section
+}
+
+// section is a single YAML document of a file, i.e. the text between
+// "---" separators.
+type section struct {
+	yaml.Document
+	// keys are the positions of the keys and items written in the
+	// section, even if it doesn't parse (see scanKeys), and entries the
+	// same sorted by line and column
+	keys    yaml.Positions
+	entries []entry
+	// positions are the positions the parser recorded, or nil if the
+	// section doesn't parse
+	positions yaml.Positions
+
+	// obj is the document decoded as a map, or nil if it didn't decode
+	obj map[string]interface{}
+	// kind is the mantle kind of the document, or empty if it isn't
+	// a mantle document
+	kind string
+	// mantle is the typed mantle object, if it decoded
+	mantle interface{}
+	// err is the first error decoding the document
+	err error
+}
+
+type entry struct {
+	path []string
+	yaml.Position
+}
+
+func newDocument(uri, text string) *document {
+	d := &document{
+		uri:   uri,
+		lines: strings.Split(text, "\n"),
+	}
+
+	for _, doc := range yaml.SplitDocuments([]byte(text)) {
+		d.sections = append(d.sections, newSection(doc))
+	}
+
+	return d
+}
+
+func newSection(doc yaml.Document) *section {
+	s := &section{
+		Document: doc,
+		keys:     scanKeys(doc.Data),
+	}
+
+	for path, pos := range s.keys {
+		s.entries = append(s.entries, entry{path: strings.Split(path, "."), Position: pos})
+	}
+	sort.Slice(s.entries, func(i, j int) bool {
+		if s.entries[i].Line != s.entries[j].Line {
+			return s.entries[i].Line < s.entries[j].Line
+		}
+		return s.entries[i].Column < s.entries[j].Column
+	})
+
+	obj := map[string]interface{}{}
+	if s.positions, s.err = yaml.UnmarshalPositions(doc.Data, &obj); s.err != nil {
+		return s
+	}
+	s.obj = obj
+
+	if codec.IsMantleType(obj) {
+		for kind := range obj {
+			s.kind = kind
+		}
+		s.mantle, s.err = codec.ParseMantleType(obj)
+	}
+
+	return s
+}
+
+// section returns the section holding the zero-based line of the file
+func (d *document) section(line int) *section {
+	var found *section
+	for _, s := range d.sections {
+		if s.Line-1 <= line {
+			found = s
+		}
+	}
+	return found
+}
+
+// line returns the text of the zero-based line, or "" past the end
+func (d *document) line(line int) string {
+	if line < 0 || line >= len(d.lines) {
+		return ""
+	}
+	return strings.TrimRight(d.lines[line], "\r")
+}
+
+// filePosition converts a position in a section to a position in the file
+func (s *section) filePosition(pos yaml.Position) Position {
+	character := 0
+	if pos.Column > 0 {
+		character = pos.Column - 1
+	}
+	return Position{Line: pos.Line + s.Line - 2, Character: character}
+}
+
+// lookup returns the position of path in the section, or of the
+// closest enclosing path that's written in it
+func (s *section) lookup(path string) (Position, bool) {
+	positions := s.positions
+	if positions == nil {
+		positions = s.keys
+	}
+	pos, ok := positions.Lookup(path)
+	if !ok {
+		return Position{}, false
+	}
+	return s.filePosition(pos), true
+}
+
+// cursor is what a position in a document points at
+type cursor struct {
+	section *section
+	// path is the path of the mapping the cursor is in, if it's on a
+	// key, or of the key whose value the cursor is on
+	path []string
+	// inValue is set when the cursor is after a key's colon
+	inValue bool
+	// key is the key on the cursor's line, if there is one, and
+	// keyPath its path
+	key     string
+	keyPath []string
+	// value is the value of the key on the cursor's line
+	value string
+	// prefix is the text between the start of the key or value and
+	// the cursor, for completions
+	prefix string
+	// keyRange is the range of the key on the cursor's line
+	keyRange Range
+}
+
+// cursorAt works out what the position points at. Like scanKeys,
+// it understands block mappings and sequences.
+func (d *document) cursorAt(pos Position) *cursor {
+	s := d.section(pos.Line)
+	if s == nil {
+		return nil
+	}
+
+	text := d.line(pos.Line)
+	character := pos.Character
+	if character > len(text) {
+		character = len(text)
+	}
+
+	// The line in the section, starting at 1 like the entries
+	line := pos.Line - s.Line + 2
+	c := &cursor{section: s}
+
+	trimmed := strings.TrimLeft(text, " ")
+	contentStart := len(text) - len(trimmed)
+	if len(trimmed) == 0 {
+		contentStart = character
+	}
+
+	var parent []string
+	if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+		dash := contentStart
+		rest := strings.TrimLeft(strings.TrimPrefix(trimmed, "-"), " ")
+		contentStart = len(text) - len(rest)
+		if len(rest) == 0 && character > dash {
+			contentStart = character
+		}
+
+		// The item on this line is recorded at the dash.
+		for _, e := range s.entries {
+			if e.Line == line && e.Column == dash+1 {
+				parent = e.path
+			}
+		}
+	} else {
+		parent = s.parentOf(line, contentStart+1)
+	}
+
+	content := text[contentStart:]
+	colon := keyColon(content)
+	if colon >= 0 {
+		c.key = strings.Trim(strings.TrimSpace(content[:colon]), `"'`)
+		c.keyPath = appendPath(parent, c.key)
+		c.value = unquote(stripComment(strings.TrimSpace(content[colon+1:])))
+		c.keyRange = Range{
+			Start: Position{Line: pos.Line, Character: contentStart},
+			End:   Position{Line: pos.Line, Character: contentStart + colon},
+		}
+	}
+
+	if colon >= 0 && character > contentStart+colon {
+		c.inValue = true
+		c.path = c.keyPath
+		c.prefix = strings.TrimLeft(text[contentStart+colon+1:character], " ")
+		return c
+	}
+
+	c.path = parent
+	if character >= contentStart {
+		c.prefix = text[contentStart:character]
+	}
+	return c
+}
+
+// parentOf returns the path of the mapping that a key at the given
+// line and column belongs to: the closest entry before it that's
+// indented less.
+func (s *section) parentOf(line, column int) []string {
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		e := s.entries[i]
+		if e.Line >= line {
+			continue
+		}
+		if e.Column < column {
+			return e.path
+		}
+	}
+	return nil
+}
+
+// keyColon returns the index of the colon ending the key of a
+// "key: value" line, or -1 if the line has no key.
+func keyColon(s string) int {
+	if strings.HasPrefix(s, `"`) || strings.HasPrefix(s, `'`) {
+		end := strings.Index(s[1:], s[:1])
+		if end < 0 {
+			return -1
+		}
+		rest := s[end+2:]
+		trimmed := strings.TrimLeft(rest, " ")
+		if !strings.HasPrefix(trimmed, ":") {
+			return -1
+		}
+		return end + 2 + len(rest) - len(trimmed)
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == ':' && (i+1 == len(s) || s[i+1] == ' ' || s[i+1] == '\t') {
+			return i
+		}
+		if s[i] == '#' && (i == 0 || s[i-1] == ' ') {
+			return -1
+		}
+	}
+	return -1
+}
+
+func stripComment(s string) string {
+	if strings.HasPrefix(s, "#") {
+		return ""
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		return strings.TrimSpace(s[:i])
+	}
+	return s
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func appendPath(path []string, segment string) []string {
+	p := make([]string, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, segment)
+}
+
+// isIndex reports whether a path segment is a sequence index
+func isIndex(segment string) bool {
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
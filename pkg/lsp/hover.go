@@ -0,0 +1,55 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"mantle/pkg/fieldmap"
+)
+
+// hover describes the field on the cursor's line: its type, and the
+// kubernetes fields it's converted to.
+func (srv *Server) hover(d *document, pos Position) *Hover {
+	c := d.cursorAt(pos)
+	if c == nil || len(c.keyPath) == 0 {
+		return nil
+	}
+
+	field, ok := fieldmap.Lookup(c.keyPath...)
+	if !ok && srv.index.At(c.keyPath) == nil {
+		return nil
+	}
+
+	var lines []string
+	header := fmt.Sprintf("**%s**", strings.Join(c.keyPath, "."))
+	if t := srv.index.Describe(srv.index.At(c.keyPath)); len(t) > 0 {
+		header += fmt.Sprintf(" `%s`", t)
+	}
+	lines = append(lines, header)
+
+	if ok {
+		if len(field.Doc) > 0 {
+			lines = append(lines, field.Doc)
+		}
+		if len(field.Default) > 0 {
+			lines = append(lines, "Default: "+field.Default)
+		}
+		if len(field.Kube) > 0 {
+			var kube []string
+			for _, path := range field.Kube {
+				kube = append(kube, "`"+path+"`")
+			}
+			lines = append(lines, "Kubernetes: "+strings.Join(kube, ", "))
+		}
+	}
+
+	if values := srv.index.Values(srv.index.At(c.keyPath)); len(values) > 0 {
+		lines = append(lines, "Values: "+strings.Join(values, ", "))
+	}
+
+	keyRange := c.keyRange
+	return &Hover{
+		Contents: MarkupContent{Kind: "markdown", Value: strings.Join(lines, "\n\n")},
+		Range:    &keyRange,
+	}
+}
@@ -0,0 +1,147 @@
+package lsp
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"mantle/internal/yaml"
+)
+
+type positionFrame struct {
+	indent int
+	path   []string
+	// item is set for sequence items
+	item bool
+	// items counts the sequence items under a key
+	items int
+}
+
+// scanKeys finds the keys and sequence items of a document line by line.
+// Unlike the YAML parser, it copes with the incomplete documents an editor
+// sends while a key is being typed, which is what completion and the
+// cursor need. It only understands block mappings and sequences: flow
+// collections ({...} and [...]) are positioned as a whole, at their key,
+// and sequence items at their dash. Errors are located with the parser's
+// positions instead.
+func scanKeys(y []byte) yaml.Positions {
+	positions := yaml.Positions{}
+
+	var stack []*positionFrame
+	blockScalarIndent := -1
+
+	for n, line := range bytes.Split(y, []byte("\n")) {
+		lineNum := n + 1
+		text := strings.TrimRight(string(line), " \t\r")
+		trimmed := strings.TrimLeft(text, " ")
+		indent := len(text) - len(trimmed)
+
+		if len(trimmed) == 0 || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		// Skip the contents of | and > block scalars.
+		if blockScalarIndent >= 0 {
+			if indent > blockScalarIndent {
+				continue
+			}
+			blockScalarIndent = -1
+		}
+
+		if strings.HasPrefix(trimmed, "---") || strings.HasPrefix(trimmed, "...") {
+			stack = nil
+			continue
+		}
+
+		if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+			for len(stack) > 0 && stack[len(stack)-1].indent > indent {
+				stack = stack[:len(stack)-1]
+			}
+
+			var parent *positionFrame
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			} else {
+				parent = &positionFrame{indent: -1}
+				stack = append(stack, parent)
+			}
+
+			itemPath := appendPath(parent.path, strconv.Itoa(parent.items))
+			parent.items++
+			positions[strings.Join(itemPath, ".")] = yaml.Position{Line: lineNum, Column: indent + 1}
+
+			rest := strings.TrimLeft(strings.TrimPrefix(trimmed, "-"), " ")
+			contentIndent := len(text) - len(rest)
+			stack = append(stack, &positionFrame{indent: contentIndent, path: itemPath, item: true})
+
+			if len(rest) == 0 {
+				continue
+			}
+
+			trimmed = rest
+			indent = contentIndent
+		} else {
+			for len(stack) > 0 {
+				top := stack[len(stack)-1]
+				if top.indent > indent || (top.indent == indent && !top.item) {
+					stack = stack[:len(stack)-1]
+					continue
+				}
+				break
+			}
+		}
+
+		key, value, ok := splitKey(trimmed)
+		if !ok {
+			continue
+		}
+
+		var parentPath []string
+		if len(stack) > 0 {
+			parentPath = stack[len(stack)-1].path
+		}
+
+		keyPath := appendPath(parentPath, key)
+		positions[strings.Join(keyPath, ".")] = yaml.Position{Line: lineNum, Column: indent + 1}
+		stack = append(stack, &positionFrame{indent: indent, path: keyPath})
+
+		if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+			blockScalarIndent = indent
+		}
+	}
+
+	return positions
+}
+
+// splitKey splits a "key: value" line. It returns false for lines
+// that aren't mapping entries, e.g. continued scalars.
+func splitKey(s string) (string, string, bool) {
+	if strings.HasPrefix(s, `"`) || strings.HasPrefix(s, `'`) {
+		quote := s[:1]
+		end := strings.Index(s[1:], quote)
+		if end < 0 {
+			return "", "", false
+		}
+		key := s[1 : end+1]
+		rest := strings.TrimLeft(s[end+2:], " ")
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", false
+		}
+		return key, strings.TrimSpace(rest[1:]), true
+	}
+
+	if strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") {
+		return "", "", false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] == ':' && (i+1 == len(s) || s[i+1] == ' ' || s[i+1] == '\t') {
+			return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:]), true
+		}
+		if s[i] == '#' && i > 0 && s[i-1] == ' ' {
+			return "", "", false
+		}
+	}
+
+	return "", "", false
+}
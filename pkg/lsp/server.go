@@ -0,0 +1,181 @@
+// Package lsp is a language server for mantle files. It speaks the
+// Language Server Protocol over a stream, usually stdio, and offers
+// completion, hover docs, diagnostics and go-to-definition, all driven
+// by the mantle schema (see pkg/schema) and field map (see pkg/fieldmap).
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"mantle/pkg/schema"
+)
+
+// ServerName is the name the server reports to editors
+const ServerName = "mantle"
+
+// Server is a language server for mantle files
+type Server struct {
+	conn     *conn
+	index    *schema.Index
+	docs     map[string]*document
+	shutdown bool
+}
+
+// NewServer returns a server that reads requests from r and writes
+// responses to w
+func NewServer(r io.Reader, w io.Writer) (*Server, error) {
+	s, err := schema.Generate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		conn:  newConn(r, w),
+		index: schema.NewIndex(s),
+		docs:  map[string]*document{},
+	}, nil
+}
+
+// Serve handles messages until the editor sends exit or closes the
+// stream. It returns an error if the editor exits without shutting
+// the server down first.
+func (srv *Server) Serve() error {
+	for {
+		data, err := srv.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		msg := &message{}
+		if err := json.Unmarshal(data, msg); err != nil {
+			if err := srv.conn.reply(nil, nil, &responseError{Code: codeParseError, Message: err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if msg.Method == "exit" {
+			if !srv.shutdown {
+				return fmt.Errorf("exit before shutdown")
+			}
+			return nil
+		}
+
+		result, err := srv.handle(msg)
+		if msg.ID == nil {
+			// Notifications have no response, even when they fail.
+			continue
+		}
+		if err := srv.conn.reply(msg.ID, result, err); err != nil {
+			return err
+		}
+	}
+}
+
+func (srv *Server) handle(msg *message) (interface{}, error) {
+	switch msg.Method {
+	case "initialize":
+		return initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:   textDocumentSyncFull,
+				CompletionProvider: completionOptions{TriggerCharacters: []string{":", " "}},
+				HoverProvider:      true,
+				DefinitionProvider: true,
+			},
+			ServerInfo: serverInfo{Name: ServerName},
+		}, nil
+
+	case "initialized":
+		return nil, nil
+
+	case "shutdown":
+		srv.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		params := didOpenParams{}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		return nil, srv.update(params.TextDocument.URI, params.TextDocument.Text)
+
+	case "textDocument/didChange":
+		params := didChangeParams{}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		if len(params.ContentChanges) == 0 {
+			return nil, nil
+		}
+		text := params.ContentChanges[len(params.ContentChanges)-1].Text
+		return nil, srv.update(params.TextDocument.URI, text)
+
+	case "textDocument/didClose":
+		params := didCloseParams{}
+		if err := unmarshalParams(msg, &params); err != nil {
+			return nil, err
+		}
+		delete(srv.docs, params.TextDocument.URI)
+		return nil, srv.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []Diagnostic{},
+		})
+
+	case "textDocument/completion":
+		d, pos, err := srv.position(msg)
+		if err != nil || d == nil {
+			return CompletionList{Items: []CompletionItem{}}, err
+		}
+		return srv.completion(d, pos), nil
+
+	case "textDocument/hover":
+		d, pos, err := srv.position(msg)
+		if err != nil || d == nil {
+			return nil, err
+		}
+		return srv.hover(d, pos), nil
+
+	case "textDocument/definition":
+		d, pos, err := srv.position(msg)
+		if err != nil || d == nil {
+			return []Location{}, err
+		}
+		return srv.definition(d, pos), nil
+
+	default:
+		return nil, &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("unsupported method %s", msg.Method)}
+	}
+}
+
+// update replaces the text of a document and publishes its diagnostics
+func (srv *Server) update(uri, text string) error {
+	d := newDocument(uri, text)
+	srv.docs[uri] = d
+
+	return srv.conn.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: srv.diagnostics(d),
+	})
+}
+
+// position returns the document and position of a request. The
+// document is nil if it isn't open.
+func (srv *Server) position(msg *message) (*document, Position, error) {
+	params := textDocumentPositionParams{}
+	if err := unmarshalParams(msg, &params); err != nil {
+		return nil, Position{}, err
+	}
+	return srv.docs[params.TextDocument.URI], params.Position, nil
+}
+
+func unmarshalParams(msg *message, params interface{}) error {
+	if err := json.Unmarshal(msg.Params, params); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: fmt.Sprintf("%s: %v", msg.Method, err)}
+	}
+	return nil
+}
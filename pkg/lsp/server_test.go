@@ -0,0 +1,210 @@
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const testURI = "file:///broker.yaml"
+
+const testDocument = `pod:
+  name: broker
+  volumes:
+    data:
+      EmptyDir: {}
+  containers:
+  - name: broker
+    image: apachepulsar/pulsar
+    pull: always
+    colour: blue
+    env:
+    - Type: from
+      From:
+        From: config
+        varNameOrPrefix: BROKER_
+        configMapOrSecretName: broker-config
+    volume:
+    - mount: /data
+      store: data
+---
+config_map:
+  name: broker-config
+  data:
+    zookeeper: zk:2181
+`
+
+func testServer(t *testing.T) (*Server, *document) {
+	srv, err := NewServer(nil, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error creating server: %v", err)
+	}
+	if err := srv.update(testURI, testDocument); err != nil {
+		t.Fatalf("unexpected error opening document: %v", err)
+	}
+	return srv, srv.docs[testURI]
+}
+
+func labels(list CompletionList) []string {
+	var result []string
+	for _, item := range list.Items {
+		result = append(result, item.Label)
+	}
+	return result
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCompletion(t *testing.T) {
+	srv, _ := testServer(t)
+
+	// A new key in the first container
+	d := newDocument(testURI, strings.Replace(testDocument, "    pull: always\n", "    pull: always\n    read\n", 1))
+	fields := labels(srv.completion(d, Position{Line: 9, Character: 8}))
+	if !contains(fields, "readiness_probe") || contains(fields, "image") {
+		t.Errorf("expected container field completions, got %v", fields)
+	}
+
+	// The value of an enum
+	values := labels(srv.completion(d, Position{Line: 8, Character: 10}))
+	if !contains(values, "always") || !contains(values, "if-not-present") {
+		t.Errorf("expected pull policy completions, got %v", values)
+	}
+
+	// A new item in containers
+	d = newDocument(testURI, strings.Replace(testDocument, "---\n", "  - \n---\n", 1))
+	fields = labels(srv.completion(d, Position{Line: 19, Character: 4}))
+	if !contains(fields, "image") || !contains(fields, "name") {
+		t.Errorf("expected container field completions in a new item, got %v", fields)
+	}
+
+	// Top-level kinds
+	d = newDocument(testURI, "")
+	if kinds := labels(srv.completion(d, Position{})); !contains(kinds, "pod") || !contains(kinds, "config_map") {
+		t.Errorf("expected kind completions, got %v", kinds)
+	}
+}
+
+func TestHover(t *testing.T) {
+	srv, d := testServer(t)
+
+	hover := srv.hover(d, Position{Line: 8, Character: 5})
+	if hover == nil {
+		t.Fatalf("expected hover on pull")
+	}
+	for _, expected := range []string{"pod.containers.0.pull", "spec.containers[*].imagePullPolicy", "if-not-present"} {
+		if !strings.Contains(hover.Contents.Value, expected) {
+			t.Errorf("expected %q in hover %q", expected, hover.Contents.Value)
+		}
+	}
+
+	if hover := srv.hover(d, Position{Line: 9, Character: 5}); hover != nil {
+		t.Errorf("expected no hover on an unknown field, got %q", hover.Contents.Value)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	srv, d := testServer(t)
+
+	diagnostics := srv.diagnostics(d)
+	if len(diagnostics) != 1 || diagnostics[0].Range.Start.Line != 9 || diagnostics[0].Severity != SeverityWarning {
+		t.Errorf("expected an unknown field warning on line 9, got %+v", diagnostics)
+	}
+
+	d = newDocument(testURI, strings.Replace(testDocument, "name: broker-config", "name: Broker_Config", 1))
+	diagnostics = srv.diagnostics(d)
+	if len(diagnostics) != 2 || diagnostics[1].Range.Start.Line != 21 || diagnostics[1].Severity != SeverityError {
+		t.Errorf("expected a validation error on line 21, got %+v", diagnostics)
+	}
+
+	d = newDocument(testURI, strings.Replace(testDocument, "pull: always", "pull: sometimes", 1))
+	diagnostics = srv.diagnostics(d)
+	if len(diagnostics) != 2 || diagnostics[0].Range.Start.Line != 8 || diagnostics[0].Severity != SeverityError {
+		t.Errorf("expected a decoding error on line 8, got %+v", diagnostics)
+	}
+}
+
+func TestDefinition(t *testing.T) {
+	srv, d := testServer(t)
+
+	locations := srv.definition(d, Position{Line: 18, Character: 14})
+	if len(locations) != 1 || locations[0].Range.Start != (Position{Line: 3, Character: 4}) {
+		t.Errorf("expected the data volume, got %+v", locations)
+	}
+
+	locations = srv.definition(d, Position{Line: 15, Character: 34})
+	if len(locations) != 1 || locations[0].Range.Start != (Position{Line: 21, Character: 2}) {
+		t.Errorf("expected the broker-config ConfigMap, got %+v", locations)
+	}
+}
+
+func frame(id int, method string, params interface{}) string {
+	msg := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+	if id > 0 {
+		msg["id"] = id
+	}
+	data, _ := json.Marshal(msg)
+	return fmt.Sprintf("Content-Length: %d\r\n\r\n%s", len(data), data)
+}
+
+func TestServe(t *testing.T) {
+	in := strings.Join([]string{
+		frame(1, "initialize", map[string]interface{}{}),
+		frame(0, "initialized", map[string]interface{}{}),
+		frame(0, "textDocument/didOpen", map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": testURI, "version": 1, "text": testDocument},
+		}),
+		frame(2, "textDocument/hover", map[string]interface{}{
+			"textDocument": map[string]interface{}{"uri": testURI},
+			"position":     map[string]interface{}{"line": 8, "character": 5},
+		}),
+		frame(3, "unknown/method", nil),
+		frame(4, "shutdown", nil),
+		frame(0, "exit", nil),
+	}, "")
+
+	out := &bytes.Buffer{}
+	srv, err := NewServer(strings.NewReader(in), out)
+	if err != nil {
+		t.Fatalf("unexpected error creating server: %v", err)
+	}
+	if err := srv.Serve(); err != nil {
+		t.Fatalf("unexpected error serving: %v", err)
+	}
+
+	c := newConn(out, nil)
+	var methods []string
+	for {
+		data, err := c.read()
+		if err != nil {
+			break
+		}
+		msg := map[string]interface{}{}
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("invalid message %s: %v", data, err)
+		}
+		if method, ok := msg["method"].(string); ok {
+			methods = append(methods, method)
+			continue
+		}
+		if _, ok := msg["error"]; ok {
+			methods = append(methods, fmt.Sprintf("error %v", msg["id"]))
+			continue
+		}
+		methods = append(methods, fmt.Sprintf("result %v", msg["id"]))
+	}
+
+	expected := "result 1,textDocument/publishDiagnostics,result 2,error 3,result 4"
+	if strings.Join(methods, ",") != expected {
+		t.Errorf("expected messages %s, got %s", expected, strings.Join(methods, ","))
+	}
+}
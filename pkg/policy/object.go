@@ -0,0 +1,74 @@
+package policy
+
+import (
+	"bytes"
+
+	"mantle/pkg/core/pod"
+	"mantle/pkg/core/pod/container"
+
+	"github.com/koki/json"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// podObject converts a pod into the generic form that policy paths
+// index into, with its containers' resource ranges expanded
+func podObject(p *pod.Pod) (map[string]interface{}, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+
+	expandResources(obj, "init_containers", p.InitContainers)
+	expandResources(obj, "containers", p.Containers)
+
+	return obj, nil
+}
+
+func expandResources(obj map[string]interface{}, key string, containers []container.Container) {
+	objContainers, _ := obj[key].([]interface{})
+	for i, c := range containers {
+		if i >= len(objContainers) {
+			return
+		}
+		objContainer, ok := objContainers[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if c.CPU != nil {
+			objContainer["cpu"] = rangeObject(c.CPU.Min, c.CPU.Max)
+		}
+		if c.Mem != nil {
+			objContainer["mem"] = rangeObject(c.Mem.Min, c.Mem.Max)
+		}
+		if c.Storage != nil {
+			objContainer["ephemeral_storage"] = rangeObject(c.Storage.Min, c.Storage.Max)
+		}
+		if len(c.Extended) > 0 {
+			extended := map[string]interface{}{}
+			for name, e := range c.Extended {
+				extended[name] = rangeObject(e.Min, e.Max)
+			}
+			objContainer["extended_resources"] = extended
+		}
+	}
+}
+
+func rangeObject(min, max *resource.Quantity) map[string]interface{} {
+	obj := map[string]interface{}{}
+	if min != nil {
+		obj["min"] = min.String()
+	}
+	if max != nil {
+		obj["max"] = max.String()
+	}
+	return obj
+}
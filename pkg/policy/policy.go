@@ -0,0 +1,341 @@
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"mantle/internal/yaml"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/lint"
+	"mantle/pkg/report"
+	"mantle/pkg/util/objutil"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+/*
+A policy file lists org-specific rules for pods:
+
+  policies:
+  - name: internal-registry
+    path: containers.*.image
+    op: matches
+    value: ^registry\.internal/
+    message: images must come from registry.internal/
+  - name: broker-cpu
+    selector:
+      component: broker
+    path: containers.*.cpu.min
+    op: less-than
+    value: 2
+    not: true
+    message: brokers must request at least 2 cpu
+
+Paths are mantle field paths, and * matches every element of a list
+or map. Resource ranges (cpu, mem, ephemeral_storage and
+extended_resources) are expanded into min and max so either end
+can be compared.
+
+A pod violates a policy if the condition is false for any value
+at the path. A value that isn't set fails every condition except
+"exists" with not, unless the policy is optional. A * over a list or
+map that isn't set or is empty matches no values, so there's nothing
+to check: containers.*.security holds for a pod with no containers.
+*/
+
+// Op compares the value at a policy's path
+type Op string
+
+const (
+	OpEquals      Op = "equals"
+	OpMatches     Op = "matches"
+	OpExists      Op = "exists"
+	OpIn          Op = "in"
+	OpLessThan    Op = "less-than"
+	OpGreaterThan Op = "greater-than"
+)
+
+// File is the contents of a policy file
+type File struct {
+	Policies []Policy `json:"policies"`
+}
+
+// Policy is a single user-defined rule
+type Policy struct {
+	Name     string            `json:"name"`
+	Message  string            `json:"message"`
+	Severity report.Severity   `json:"severity,omitempty"`
+	Selector map[string]string `json:"selector,omitempty"`
+	Path     string            `json:"path"`
+	Op       Op                `json:"op"`
+	Value    interface{}       `json:"value,omitempty"`
+	Not      bool              `json:"not,omitempty"`
+	Optional bool              `json:"optional,omitempty"`
+
+	path   []string
+	regexp *regexp.Regexp
+	values []interface{}
+	limit  *resource.Quantity
+}
+
+// Load reads the policies in the given files
+func Load(paths ...string) ([]*Policy, error) {
+	var policies []*Policy
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		filePolicies, err := Parse(data)
+		if err != nil {
+			if located, ok := err.(*yaml.Error); ok {
+				located.File = path
+				return nil, located
+			}
+			return nil, serrors.ContextualizeErrorf(err, path)
+		}
+
+		policies = append(policies, filePolicies...)
+	}
+
+	return policies, nil
+}
+
+// Parse reads the policies in a policy file
+func Parse(data []byte) ([]*Policy, error) {
+	file := File{}
+	positions, err := yaml.UnmarshalPositions(data, &file)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []*Policy
+	for i := range file.Policies {
+		policy := &file.Policies[i]
+		if err := policy.compile(); err != nil {
+			located, ok := yaml.Locate(objutil.ErrorAtPath(err, "policies", i), positions)
+			if ok {
+				return nil, located
+			}
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// compile checks the policy and prepares it for evaluation
+func (p *Policy) compile() error {
+	if len(p.Name) == 0 {
+		return objutil.ErrorAtPath(serrors.InvalidInstanceErrorf(p, "policies must have a name"), "name")
+	}
+
+	switch p.Severity {
+	case "":
+		p.Severity = report.SeverityError
+	case report.SeverityError, report.SeverityWarning, report.SeverityInfo:
+	default:
+		return objutil.ErrorAtPath(serrors.InvalidValueErrorf(p.Severity, "expected %s, %s or %s",
+			report.SeverityError, report.SeverityWarning, report.SeverityInfo), "severity")
+	}
+
+	if len(p.Path) == 0 {
+		return objutil.ErrorAtPath(serrors.InvalidInstanceErrorf(p, "policies must have a path"), "path")
+	}
+	p.path = strings.Split(p.Path, ".")
+
+	switch p.Op {
+	case OpExists:
+	case OpEquals:
+		if p.Value == nil {
+			return objutil.ErrorAtPath(serrors.InvalidInstanceErrorf(p, "%s needs a value", p.Op), "value")
+		}
+	case OpMatches:
+		str, ok := p.Value.(string)
+		if !ok {
+			return objutil.ErrorAtPath(serrors.InvalidValueErrorf(p.Value, "%s needs a regular expression", p.Op), "value")
+		}
+		re, err := regexp.Compile(str)
+		if err != nil {
+			return objutil.ErrorAtPath(serrors.InvalidValueContextErrorf(err, str, "invalid regular expression"), "value")
+		}
+		p.regexp = re
+	case OpIn:
+		values, ok := p.Value.([]interface{})
+		if !ok {
+			return objutil.ErrorAtPath(serrors.InvalidValueErrorf(p.Value, "%s needs a list of values", p.Op), "value")
+		}
+		p.values = values
+	case OpLessThan, OpGreaterThan:
+		limit, err := parseQuantity(p.Value)
+		if err != nil {
+			return objutil.ErrorAtPath(err, "value")
+		}
+		p.limit = limit
+	default:
+		return objutil.ErrorAtPath(serrors.InvalidValueErrorf(p.Op, "expected %s, %s, %s, %s, %s or %s",
+			OpEquals, OpMatches, OpExists, OpIn, OpLessThan, OpGreaterThan), "op")
+	}
+
+	return nil
+}
+
+func parseQuantity(value interface{}) (*resource.Quantity, error) {
+	switch value.(type) {
+	case string, json.Number, float64, int, int64:
+	default:
+		return nil, serrors.InvalidValueErrorf(value, "expected a quantity, e.g. 2, 500m or 1Gi")
+	}
+
+	q, err := resource.ParseQuantity(scalarString(value))
+	if err != nil {
+		return nil, serrors.InvalidValueContextErrorf(err, value, "expected a quantity, e.g. 2, 500m or 1Gi")
+	}
+
+	return &q, nil
+}
+
+// Rules returns the policies as lint rules, so they can be
+// run (and disabled) alongside the built-in rules
+func Rules(policies []*Policy) []lint.Rule {
+	var rules []lint.Rule
+	for _, policy := range policies {
+		rules = append(rules, lint.NewRule(policy.Name, policy.Severity, policy.Message, policy.Check))
+	}
+	return rules
+}
+
+// Check evaluates the policy against a pod. Wildcards that match
+// nothing yield no paths, and so no problems.
+func (p *Policy) Check(mantlePod *pod.Pod) []lint.Problem {
+	if !p.selects(mantlePod) {
+		return nil
+	}
+
+	obj, err := podObject(mantlePod)
+	if err != nil {
+		return []lint.Problem{{Message: fmt.Sprintf("%s (couldn't evaluate policy: %v)", p.Message, err)}}
+	}
+
+	var problems []lint.Problem
+	for _, path := range objutil.ExpandPathIn(obj, p.path) {
+		value, err := objutil.AtPathIn(obj, path)
+		exists := err == nil && value != nil
+
+		if !exists && p.Optional {
+			continue
+		}
+
+		if ok, detail := p.holds(value, exists); !ok {
+			problems = append(problems, lint.Problem{
+				Path:    formatPath(path),
+				Message: fmt.Sprintf("%s (%s)", p.Message, detail),
+			})
+		}
+	}
+
+	return problems
+}
+
+func (p *Policy) selects(mantlePod *pod.Pod) bool {
+	for key, value := range p.Selector {
+		if v, ok := mantlePod.Labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// holds reports whether the policy's condition holds for a value,
+// and if not, describes the value
+func (p *Policy) holds(value interface{}, exists bool) (bool, string) {
+	if p.Op == OpExists {
+		if exists == !p.Not {
+			return true, ""
+		}
+		if exists {
+			return false, fmt.Sprintf("got %v", value)
+		}
+		return false, "not set"
+	}
+
+	if !exists {
+		return false, "not set"
+	}
+
+	var ok bool
+	switch p.Op {
+	case OpEquals:
+		ok = equal(value, p.Value)
+	case OpMatches:
+		str, isString := value.(string)
+		ok = isString && p.regexp.MatchString(str)
+	case OpIn:
+		for _, v := range p.values {
+			ok = ok || equal(value, v)
+		}
+	case OpLessThan, OpGreaterThan:
+		q, err := parseQuantity(value)
+		if err != nil {
+			return false, fmt.Sprintf("%v isn't a quantity", value)
+		}
+		if p.Op == OpLessThan {
+			ok = q.Cmp(*p.limit) < 0
+		} else {
+			ok = q.Cmp(*p.limit) > 0
+		}
+	}
+
+	if ok == p.Not {
+		return false, fmt.Sprintf("got %v", value)
+	}
+	return true, ""
+}
+
+// equal compares scalars by their string form, since YAML and
+// mantle objects don't always agree on types (e.g. "80" and 80)
+func equal(a, b interface{}) bool {
+	switch a.(type) {
+	case map[string]interface{}, []interface{}:
+		return reflect.DeepEqual(a, b)
+	}
+	switch b.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return scalarString(a) == scalarString(b)
+}
+
+// scalarString formats numbers without exponents, so that values
+// decoded as float64 compare equal to the numbers in mantle objects
+func scalarString(value interface{}) string {
+	if f, ok := value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// formatPath writes a path the way findings do, e.g. containers[0].image
+func formatPath(path []string) string {
+	var formatted string
+	for _, key := range path {
+		if _, err := strconv.Atoi(key); err == nil {
+			formatted += "[" + key + "]"
+			continue
+		}
+		if len(formatted) > 0 {
+			formatted += "."
+		}
+		formatted += key
+	}
+	return formatted
+}
@@ -0,0 +1,162 @@
+package policy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"mantle/internal/yaml"
+	"mantle/pkg/bundle"
+	"mantle/pkg/lint"
+)
+
+const testPolicies = `
+policies:
+- name: internal-registry
+  path: containers.*.image
+  op: matches
+  value: ^registry\.internal/
+  message: images must come from registry.internal/
+- name: broker-cpu
+  selector:
+    component: broker
+  path: containers.*.cpu.min
+  op: less-than
+  value: 2
+  not: true
+  message: brokers must request at least 2 cpu
+- name: working-dir
+  severity: warning
+  path: containers.*.wd
+  op: in
+  value: [/app, /pulsar]
+  optional: true
+  message: containers must run in /app or /pulsar
+- name: team-label
+  path: labels.team
+  op: exists
+  message: pods must have a team label
+`
+
+const testBundle = `
+pod:
+  name: broker
+  labels:
+    component: broker
+    team: messaging
+  containers:
+  - name: broker
+    image: registry.internal/pulsar:2.1
+    cpu: 500m-4
+    wd: /pulsar
+  - name: sidecar
+    image: envoy:1.7
+    cpu: 2
+    wd: /tmp
+---
+pod:
+  name: bookie
+  labels:
+    component: bookie
+  containers:
+  - name: bookie
+    image: registry.internal/pulsar:2.1
+`
+
+func TestPolicies(t *testing.T) {
+	policies, err := Parse([]byte(testPolicies))
+	if err != nil {
+		t.Fatalf("unexpected error parsing policies: %v", err)
+	}
+
+	objects, err := bundle.Read("bundle.yaml", strings.NewReader(testBundle))
+	if err != nil {
+		t.Fatalf("unexpected error reading bundle: %v", err)
+	}
+
+	findings, err := (&lint.Linter{Rules: Rules(policies)}).Bundle(&bundle.Bundle{Objects: objects})
+	if err != nil {
+		t.Fatalf("unexpected error checking policies: %v", err)
+	}
+
+	var results []string
+	for _, finding := range findings {
+		results = append(results, finding.Object+" "+finding.Path+" "+finding.Rule+" "+string(finding.Severity))
+	}
+
+	expected := []string{
+		"Pod/broker containers[1].image internal-registry error",
+		"Pod/broker containers[0].cpu.min broker-cpu error",
+		"Pod/broker containers[1].wd working-dir warning",
+		"Pod/bookie labels.team team-label error",
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected findings %v, got %v", expected, results)
+	}
+}
+
+func TestParsePolicyErrors(t *testing.T) {
+	tests := map[string]string{
+		"policies:\n- name: a\n  path: name\n  op: bigger\n":                "4:3",
+		"policies:\n- name: a\n  path: name\n  op: matches\n  value: '('\n": "5:3",
+		"policies:\n- name: a\n  op: exists\n":                              "2:3",
+		"policies:\n- name: a\n  path: name\n  op: less-than\n  value: x\n": "5:3",
+	}
+
+	for policies, position := range tests {
+		_, err := Parse([]byte(policies))
+		located, ok := err.(*yaml.Error)
+		if !ok {
+			t.Errorf("%q: expected a located error, got %v", policies, err)
+			continue
+		}
+		if located.Position.String() != position {
+			t.Errorf("%q: expected error at %s, got %s", policies, position, located.Position)
+		}
+	}
+}
+
+func TestMissingWildcardParent(t *testing.T) {
+	policies, err := Parse([]byte(`
+policies:
+- name: init-security
+  path: init_containers.*.security
+  op: exists
+  message: init containers must set a security context
+- name: env
+  path: containers.*.env.*.value
+  op: exists
+  message: env vars must have values
+- name: first-container-security
+  path: containers.0.security.privileged
+  op: exists
+  message: the first container must set privileged
+`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing policies: %v", err)
+	}
+
+	objects, err := bundle.Read("bundle.yaml", strings.NewReader(testBundle))
+	if err != nil {
+		t.Fatalf("unexpected error reading bundle: %v", err)
+	}
+
+	findings, err := (&lint.Linter{Rules: Rules(policies)}).Bundle(&bundle.Bundle{Objects: objects})
+	if err != nil {
+		t.Fatalf("unexpected error checking policies: %v", err)
+	}
+
+	// The pods have no init containers or env, so only the path without
+	// a wildcard is reported as not set.
+	var results []string
+	for _, finding := range findings {
+		results = append(results, finding.Object+" "+finding.Path+" "+finding.Rule)
+	}
+	expected := []string{
+		"Pod/broker containers[0].security.privileged first-container-security",
+		"Pod/bookie containers[0].security.privileged first-container-security",
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected findings %v, got %v", expected, results)
+	}
+}
@@ -0,0 +1,404 @@
+package references
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/core/configmap"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/core/pod/container"
+	"mantle/pkg/core/pod/container/env"
+	"mantle/pkg/core/pod/volume/util"
+	"mantle/pkg/report"
+
+	serrors "github.com/koki/structurederrors"
+
+	"k8s.io/api/core/v1"
+)
+
+// Kinds of the objects that pods reference
+const (
+	KindConfigMap      = "ConfigMap"
+	KindSecret         = "Secret"
+	KindPVC            = "PersistentVolumeClaim"
+	KindServiceAccount = "ServiceAccount"
+	KindPriorityClass  = "PriorityClass"
+)
+
+const (
+	ruleDanglingReference = "dangling-reference"
+	ruleMissingKey        = "missing-key"
+)
+
+const defaultNamespace = "default"
+
+// builtinAllowlist holds the objects every cluster has
+var builtinAllowlist = []string{
+	KindServiceAccount + "/default",
+	KindPriorityClass + "/system-cluster-critical",
+	KindPriorityClass + "/system-node-critical",
+}
+
+// Allowlist holds references to objects that exist outside the bundle.
+// Entries are Kind/name, which matches any namespace, or
+// Kind/namespace/name. Kinds are case-insensitive and a name
+// of * matches every object of the kind.
+type Allowlist []string
+
+// LoadAllowlist reads an allowlist file, one entry per line.
+// Blank lines and lines starting with # are skipped.
+func LoadAllowlist(path string) (Allowlist, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var allowlist Allowlist
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		allowlist = append(allowlist, line)
+	}
+
+	return allowlist, scanner.Err()
+}
+
+// Validate checks that every entry is Kind/name or Kind/namespace/name
+func (a Allowlist) Validate() error {
+	for _, entry := range a {
+		if n := len(strings.Split(entry, "/")); n != 2 && n != 3 {
+			return serrors.InvalidValueErrorf(entry, "expected Kind/name or Kind/namespace/name")
+		}
+	}
+	return nil
+}
+
+func (a Allowlist) allows(r ref) bool {
+	for _, entry := range append(builtinAllowlist, a...) {
+		parts := strings.Split(entry, "/")
+		if !strings.EqualFold(parts[0], r.kind) {
+			continue
+		}
+
+		name := parts[len(parts)-1]
+		if len(parts) == 3 && parts[1] != r.namespace {
+			continue
+		}
+		if name == "*" || name == r.name {
+			return true
+		}
+	}
+	return false
+}
+
+// ref identifies a referenced object. Namespace is empty
+// for cluster-scoped kinds.
+type ref struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (r ref) String() string {
+	if len(r.namespace) > 0 {
+		return fmt.Sprintf("%s/%s/%s", r.kind, r.namespace, r.name)
+	}
+	return fmt.Sprintf("%s/%s", r.kind, r.name)
+}
+
+func newRef(kind, namespace, name string) ref {
+	if kind == KindPriorityClass {
+		namespace = ""
+	} else if len(namespace) == 0 {
+		namespace = defaultNamespace
+	}
+	return ref{kind: kind, namespace: namespace, name: name}
+}
+
+// Check reports the references made by the pods and workloads in the
+// bundle that don't resolve to an object in the bundle or the allowlist,
+// and the ConfigMap and Secret keys that are missing from the
+// objects they reference. References marked optional are
+// reported as warnings.
+func Check(b *bundle.Bundle, allowlist Allowlist) ([]report.Finding, error) {
+	if err := allowlist.Validate(); err != nil {
+		return nil, err
+	}
+
+	objects := NewIndex(b)
+
+	templates, err := b.PodTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []report.Finding
+	for _, template := range templates {
+		c := &checker{objects: objects, allowlist: allowlist, namespace: template.Pod.Namespace}
+		c.pod(template.Pod)
+
+		for _, finding := range c.findings {
+			finding.Object = template.Object.ID()
+			finding.Location = template.Object.Location()
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, nil
+}
+
+// How a pod references an object
+const (
+	ViaEnv           = "env"
+	ViaVolume        = "volume"
+	ViaRegistry      = "registry"
+	ViaAccount       = "account"
+	ViaPriorityClass = "priority-class"
+)
+
+// Reference is a reference from a pod to another object
+type Reference struct {
+	Kind string
+	Name string
+	// Keys are the ConfigMap or Secret keys the pod uses,
+	// if it doesn't use the whole object
+	Keys     []string
+	Optional bool
+	Via      string
+	// Path is the mantle field path of the reference in the pod
+	Path string
+}
+
+// PodReferences returns the objects referenced by a pod,
+// in the order of their fields
+func PodReferences(p *pod.Pod) []Reference {
+	var refs []Reference
+
+	p.EachContainer(func(ctr *container.Container, path string) {
+		for i, e := range ctr.Env {
+			if r, ok := envReference(e); ok {
+				r.Path = fmt.Sprintf("%s.env[%d]", path, i)
+				refs = append(refs, r)
+			}
+		}
+	})
+
+	for _, name := range p.VolumeNames() {
+		v := p.Volumes[name]
+		path := fmt.Sprintf("volumes.%s", name)
+		switch {
+		case v.ConfigMap != nil:
+			refs = append(refs, volumeReference(KindConfigMap, v.ConfigMap.Name, v.ConfigMap.Items, v.ConfigMap.Required, path))
+		case v.Secret != nil:
+			refs = append(refs, volumeReference(KindSecret, v.Secret.SecretName, v.Secret.Items, v.Secret.Required, path))
+		case v.PVC != nil:
+			refs = append(refs, Reference{Kind: KindPVC, Name: v.PVC.ClaimName, Via: ViaVolume, Path: path})
+		case v.Projected != nil:
+			for i, source := range v.Projected.Sources {
+				sourcePath := fmt.Sprintf("%s.sources[%d]", path, i)
+				if source.ConfigMap != nil {
+					refs = append(refs, volumeReference(KindConfigMap, source.ConfigMap.Name, source.ConfigMap.Items, source.ConfigMap.Required, sourcePath))
+				}
+				if source.Secret != nil {
+					refs = append(refs, volumeReference(KindSecret, source.Secret.Name, source.Secret.Items, source.Secret.Required, sourcePath))
+				}
+			}
+		}
+	}
+
+	for i, registry := range p.Registries {
+		refs = append(refs, Reference{Kind: KindSecret, Name: registry, Via: ViaRegistry, Path: fmt.Sprintf("registry_secrets[%d]", i)})
+	}
+
+	if len(p.Account) > 0 {
+		refs = append(refs, Reference{Kind: KindServiceAccount, Name: p.Account, Via: ViaAccount, Path: "account"})
+	}
+
+	if len(p.PriorityClass) > 0 {
+		refs = append(refs, Reference{Kind: KindPriorityClass, Name: p.PriorityClass, Via: ViaPriorityClass, Path: "priorityClass"})
+	}
+
+	return refs
+}
+
+func volumeReference(kind, name string, items map[string]util.KeyAndMode, required *bool, path string) Reference {
+	return Reference{
+		Kind:     kind,
+		Name:     name,
+		Keys:     itemKeys(items),
+		Optional: isOptional(required),
+		Via:      ViaVolume,
+		Path:     path,
+	}
+}
+
+func envReference(e env.Env) (Reference, bool) {
+	if e.Type != env.EnvFromEnvType || e.From == nil {
+		return Reference{}, false
+	}
+
+	from := e.From
+	r := Reference{
+		Name:     from.ConfigMapOrSecretName,
+		Optional: isOptional(from.Required),
+		Via:      ViaEnv,
+	}
+	switch from.From {
+	case env.EnvFromTypeConfig:
+		r.Kind = KindConfigMap
+	case env.EnvFromTypeSecret:
+		r.Kind = KindSecret
+	default:
+		return Reference{}, false
+	}
+
+	if len(from.ConfigMapOrSecretKey) > 0 {
+		r.Keys = []string{from.ConfigMapOrSecretKey}
+	}
+	return r, true
+}
+
+// Index finds the objects in a bundle by kind, namespace and name
+type Index map[ref]*bundle.Object
+
+// NewIndex indexes the objects in a bundle
+func NewIndex(b *bundle.Bundle) Index {
+	index := Index{}
+	for _, object := range b.Objects {
+		index[newRef(object.Kind, object.Namespace, object.Name)] = object
+	}
+	return index
+}
+
+// Lookup returns the object, or nil if it isn't in the bundle. An empty
+// namespace is the default namespace, and cluster-scoped kinds
+// ignore the namespace.
+func (i Index) Lookup(kind, namespace, name string) *bundle.Object {
+	return i[newRef(kind, namespace, name)]
+}
+
+type checker struct {
+	objects   Index
+	allowlist Allowlist
+	namespace string
+	findings  []report.Finding
+}
+
+func (c *checker) pod(p *pod.Pod) {
+	for _, r := range PodReferences(p) {
+		switch r.Kind {
+		case KindConfigMap, KindSecret:
+			c.keys(r.Kind, r.Name, r.Keys, r.Optional, r.Path)
+		default:
+			c.resolve(r.Kind, r.Name, r.Optional, r.Path)
+		}
+	}
+}
+
+// resolve reports a reference that doesn't resolve, returning
+// the referenced object if it's in the bundle
+func (c *checker) resolve(kind, name string, optional bool, path string) *bundle.Object {
+	r := newRef(kind, c.namespace, name)
+	if object, ok := c.objects[r]; ok {
+		return object
+	}
+
+	if !c.allowlist.allows(r) {
+		c.fail(ruleDanglingReference, optional, path, "%s isn't in the bundle or the allowlist", r)
+	}
+	return nil
+}
+
+// keys resolves a ConfigMap or Secret and reports the given keys
+// that are missing from it
+func (c *checker) keys(kind, name string, keys []string, optional bool, path string) {
+	object := c.resolve(kind, name, optional, path)
+	if object == nil {
+		return
+	}
+
+	objectKeys, ok := dataKeys(object)
+	if !ok {
+		return
+	}
+
+	for _, key := range keys {
+		if !objectKeys[key] {
+			c.fail(ruleMissingKey, optional, path, "%s has no key %s", object.ID(), key)
+		}
+	}
+}
+
+func (c *checker) fail(rule string, optional bool, path, msgFormat string, args ...interface{}) {
+	severity := report.SeverityError
+	if optional {
+		severity = report.SeverityWarning
+		msgFormat += " (optional)"
+	}
+
+	c.findings = append(c.findings, report.Finding{
+		Rule:     rule,
+		Severity: severity,
+		Path:     path,
+		Message:  fmt.Sprintf(msgFormat, args...),
+	})
+}
+
+// dataKeys returns the keys of a ConfigMap or Secret
+func dataKeys(object *bundle.Object) (map[string]bool, bool) {
+	keys := map[string]bool{}
+	switch obj := object.Mantle.(type) {
+	case *configmap.ConfigMap:
+		for key := range obj.Data {
+			keys[key] = true
+		}
+		for key := range obj.BinaryData {
+			keys[key] = true
+		}
+		return keys, true
+	}
+
+	switch obj := object.Kube.(type) {
+	case *v1.ConfigMap:
+		for key := range obj.Data {
+			keys[key] = true
+		}
+		for key := range obj.BinaryData {
+			keys[key] = true
+		}
+		return keys, true
+	case *v1.Secret:
+		for key := range obj.Data {
+			keys[key] = true
+		}
+		for key := range obj.StringData {
+			keys[key] = true
+		}
+		return keys, true
+	}
+
+	return nil, false
+}
+
+// isOptional converts a mantle Required field, which defaults to true
+func isOptional(required *bool) bool {
+	return required != nil && !*required
+}
+
+// itemKeys returns the keys projected by a volume's items in a stable order
+func itemKeys(items map[string]util.KeyAndMode) []string {
+	var keys []string
+	for _, item := range items {
+		keys = append(keys, item.Key)
+	}
+	sort.Strings(keys)
+	return keys
+}
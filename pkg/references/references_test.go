@@ -0,0 +1,119 @@
+package references
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"mantle/pkg/bundle"
+)
+
+const testBundle = `
+config_map:
+  name: broker-config
+  namespace: pulsar
+  data:
+    PULSAR_MEM: 2g
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: tls
+  namespace: pulsar
+data:
+  tls.crt: Y2VydA==
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: broker
+  namespace: pulsar
+spec:
+  template:
+    spec:
+      serviceAccountName: broker
+      priorityClassName: system-node-critical
+      imagePullSecrets:
+      - name: registry-creds
+      containers:
+      - name: broker
+        image: apachepulsar/pulsar:2.1
+        env:
+        - name: PULSAR_MEM
+          valueFrom:
+            configMapKeyRef:
+              name: broker-config
+              key: PULSAR_MEM
+        - name: PULSAR_GC
+          valueFrom:
+            configMapKeyRef:
+              name: broker-config
+              key: PULSAR_GC
+        - name: ZK
+          valueFrom:
+            configMapKeyRef:
+              name: zookeeper-config
+              key: servers
+              optional: true
+      volumes:
+      - name: certs
+        secret:
+          secretName: tls
+          items:
+          - key: tls.key
+            path: tls.key
+      - name: data
+        persistentVolumeClaim:
+          claimName: broker-data
+`
+
+func checkTestBundle(t *testing.T, allowlist Allowlist) []string {
+	objects, err := bundle.Read("bundle.yaml", strings.NewReader(testBundle))
+	if err != nil {
+		t.Fatalf("unexpected error reading bundle: %v", err)
+	}
+
+	findings, err := Check(&bundle.Bundle{Objects: objects}, allowlist)
+	if err != nil {
+		t.Fatalf("unexpected error checking references: %v", err)
+	}
+
+	var results []string
+	for _, finding := range findings {
+		results = append(results, finding.Path+" "+finding.Rule+" "+string(finding.Severity)+": "+finding.Message)
+	}
+	return results
+}
+
+func TestCheck(t *testing.T) {
+	expected := []string{
+		"containers[0].env[1] missing-key error: ConfigMap/pulsar/broker-config has no key PULSAR_GC",
+		"containers[0].env[2] dangling-reference warning: ConfigMap/pulsar/zookeeper-config isn't in the bundle or the allowlist (optional)",
+		"volumes.certs missing-key error: Secret/pulsar/tls has no key tls.key",
+		"volumes.data dangling-reference error: PersistentVolumeClaim/pulsar/broker-data isn't in the bundle or the allowlist",
+		"registry_secrets[0] dangling-reference error: Secret/pulsar/registry-creds isn't in the bundle or the allowlist",
+		"account dangling-reference error: ServiceAccount/pulsar/broker isn't in the bundle or the allowlist",
+	}
+
+	if results := checkTestBundle(t, nil); !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected findings\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(results, "\n"))
+	}
+}
+
+func TestCheckAllowlist(t *testing.T) {
+	allowlist := Allowlist{
+		"secret/pulsar/registry-creds",
+		"ServiceAccount/broker",
+		"PersistentVolumeClaim/*",
+		"ConfigMap/other/zookeeper-config",
+	}
+
+	results := checkTestBundle(t, allowlist)
+	if len(results) != 3 {
+		t.Errorf("expected the allowlisted references to resolve, got %v", results)
+	}
+
+	if err := (Allowlist{"Secret"}).Validate(); err == nil {
+		t.Errorf("expected an error for an allowlist entry without a name")
+	}
+}
@@ -0,0 +1,83 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/koki/json"
+)
+
+// Severity ranks how serious a finding is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Finding is a single problem found in a bundle object.
+// Path is the mantle field path of the problem within the object,
+// e.g. containers[1].cap_add
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Object   string   `json:"object"`
+	Location string   `json:"location,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	var prefix []string
+	for _, part := range []string{f.Location, f.Object, f.Path} {
+		if len(part) > 0 {
+			prefix = append(prefix, part)
+		}
+	}
+
+	return fmt.Sprintf("%s: %s [%s]: %s", strings.Join(prefix, ": "), f.Severity, f.Rule, f.Message)
+}
+
+// Output formats for findings
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Print writes the findings to w in the given format
+func Print(w io.Writer, findings []Finding, format string) error {
+	switch format {
+	case FormatText, "":
+		for _, finding := range findings {
+			if _, err := fmt.Fprintln(w, finding); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatJSON:
+		if findings == nil {
+			findings = []Finding{}
+		}
+		data, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// Count returns the number of findings with the given severity
+func Count(findings []Finding, severity Severity) int {
+	n := 0
+	for _, finding := range findings {
+		if finding.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,274 @@
+// Package roundtrip checks what mantle loses when it converts kubernetes
+// objects: each object is converted to mantle and back, and every field
+// that's dropped, changed or added along the way is reported.
+package roundtrip
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/codec"
+	"mantle/pkg/report"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Rules of the findings
+const (
+	RuleDropped     = "dropped"
+	RuleChanged     = "changed"
+	RuleAdded       = "added"
+	RuleUnsupported = "unsupported"
+)
+
+// Bundle round-trips every kubernetes pod and config map in the bundle,
+// and the pod template of every workload. Findings are paths of the
+// kubernetes object, e.g. spec.template.spec.securityContext.sysctls.
+// Mantle objects and objects of other kinds are skipped.
+func Bundle(b *bundle.Bundle) ([]report.Finding, error) {
+	var findings []report.Finding
+
+	for _, object := range b.Objects {
+		if object.Kube == nil {
+			continue
+		}
+
+		objectFindings, err := Object(object)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, finding := range objectFindings {
+			finding.Object = object.ID()
+			finding.Location = object.Location()
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings, nil
+}
+
+// Object round-trips a kubernetes object of the bundle. Workloads are
+// checked by their pod template only, since mantle has no workloads.
+func Object(object *bundle.Object) ([]report.Finding, error) {
+	switch object.Kube.(type) {
+	case *v1.Pod, *v1.ConfigMap:
+		return Kube(object.Kube, "")
+	}
+
+	template, path := object.KubePodTemplate()
+	if template == nil {
+		return nil, nil
+	}
+
+	return Kube(&v1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: template.ObjectMeta,
+		Spec:       template.Spec,
+	}, formatPath(path))
+}
+
+// Kube converts the kubernetes object to mantle and back, and compares
+// the result with the object. The paths of the findings start with
+// prefix. If the object can't be converted, the reason is reported as
+// an unsupported finding. Fields that are equal to the kubernetes
+// defaults aren't differences, whether they're written or not.
+func Kube(kubeObj runtime.Object, prefix string) ([]report.Finding, error) {
+	before, err := tree(withoutDefaults(kubeObj))
+	if err != nil {
+		return nil, err
+	}
+
+	mantleObj, err := codec.FromKube(kubeObj)
+	if err != nil {
+		return []report.Finding{unsupported(prefix, err)}, nil
+	}
+	converted, err := codec.ToKube(mantleObj)
+	if err != nil {
+		return []report.Finding{unsupported(prefix, err)}, nil
+	}
+
+	after, err := tree(withoutDefaults(converted))
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the pod template of a workload is round-tripped, so the
+	// envelope of the pod it's wrapped in isn't compared.
+	if len(prefix) > 0 {
+		for _, m := range []interface{}{before, after} {
+			if m, ok := m.(map[string]interface{}); ok {
+				delete(m, "apiVersion")
+				delete(m, "kind")
+				delete(m, "status")
+			}
+		}
+	}
+
+	var findings []report.Finding
+	compare(prefix, before, after, &findings)
+	return findings, nil
+}
+
+// withoutDefaults returns a copy of the kubernetes object without the
+// fields that are equal to the kubernetes defaults
+func withoutDefaults(kubeObj runtime.Object) runtime.Object {
+	kubeObj = kubeObj.DeepCopyObject()
+	codec.StripDefaults(kubeObj)
+	return kubeObj
+}
+
+// tree converts a kubernetes object into maps, lists and scalars, as
+// it's written in a manifest
+func tree(kubeObj runtime.Object) (interface{}, error) {
+	data, err := json.Marshal(kubeObj)
+	if err != nil {
+		return nil, err
+	}
+
+	var t interface{}
+	err = json.Unmarshal(data, &t)
+	return t, err
+}
+
+func unsupported(path string, err error) report.Finding {
+	return report.Finding{
+		Rule:     RuleUnsupported,
+		Severity: report.SeverityError,
+		Path:     path,
+		Message:  fmt.Sprintf("can't be converted to mantle: %v", err),
+	}
+}
+
+// compare reports the differences between the values at path. Lists are
+// compared item by item.
+func compare(path string, before, after interface{}, findings *[]report.Finding) {
+	if isEmpty(before) && isEmpty(after) {
+		return
+	}
+	if isEmpty(after) {
+		*findings = append(*findings, difference(RuleDropped, path, "dropped %s", format(before)))
+		return
+	}
+	if isEmpty(before) {
+		*findings = append(*findings, difference(RuleAdded, path, "added %s", format(after)))
+		return
+	}
+
+	switch b := before.(type) {
+	case map[string]interface{}:
+		a, ok := after.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := map[string]bool{}
+		for key := range b {
+			keys[key] = true
+		}
+		for key := range a {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+
+		for _, key := range sorted {
+			compare(appendKey(path, key), b[key], a[key], findings)
+		}
+		return
+	case []interface{}:
+		a, ok := after.([]interface{})
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(b) || i < len(a); i++ {
+			var itemBefore, itemAfter interface{}
+			if i < len(b) {
+				itemBefore = b[i]
+			}
+			if i < len(a) {
+				itemAfter = a[i]
+			}
+			compare(fmt.Sprintf("%s[%d]", path, i), itemBefore, itemAfter, findings)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*findings = append(*findings, difference(RuleChanged, path, "changed from %s to %s", format(before), format(after)))
+	}
+}
+
+func difference(rule, path, message string, args ...interface{}) report.Finding {
+	return report.Finding{
+		Rule:     rule,
+		Severity: report.SeverityError,
+		Path:     path,
+		Message:  fmt.Sprintf(message, args...),
+	}
+}
+
+// isEmpty reports whether a value is missing, or an empty string, list
+// or object. Kubernetes treats those the same, so they aren't
+// differences. False and zero are values of their own, e.g. for
+// optional bools.
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	case string:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// format writes a value as compact JSON
+func format(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// appendKey appends a key to a kubernetes field path the way kubernetes
+// errors do. Keys that aren't plain names, like annotation keys, are
+// written in brackets.
+func appendKey(path, key string) string {
+	switch {
+	case strings.ContainsAny(key, "./"):
+		return fmt.Sprintf("%s[%s]", path, key)
+	case len(path) == 0:
+		return key
+	default:
+		return path + "." + key
+	}
+}
+
+func formatPath(path []interface{}) string {
+	var result string
+	for _, segment := range path {
+		switch segment := segment.(type) {
+		case int:
+			result = fmt.Sprintf("%s[%d]", result, segment)
+		default:
+			result = appendKey(result, fmt.Sprint(segment))
+		}
+	}
+	return result
+}
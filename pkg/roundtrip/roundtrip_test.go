@@ -0,0 +1,204 @@
+package roundtrip
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/report"
+)
+
+const testBundle = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: broker
+  generateName: broker-
+  finalizers: [pulsar.apache.org/cleanup]
+  labels:
+    app: pulsar
+spec:
+  securityContext:
+    sysctls:
+    - name: net.core.somaxconn
+      value: "1024"
+  containers:
+  - name: broker
+    image: apachepulsar/pulsar
+    imagePullPolicy: IfNotPresent
+    ports:
+    - name: http
+      containerPort: 8080
+    - name: pulsar
+      containerPort: 6650
+      protocol: TCP
+    - name: discovery
+      containerPort: 5353
+      protocol: UDP
+    envFrom:
+    - configMapRef:
+        name: broker-config
+    env:
+    - name: PULSAR_MEM
+      value: 2g
+    volumeDevices:
+    - name: journal
+      devicePath: /dev/journal
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: zookeeper
+spec:
+  tolerations:
+  - key: dedicated
+    operator: Equal
+    value: pulsar
+  containers:
+  - name: zookeeper
+    image: apachepulsar/pulsar
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: bookie
+spec:
+  template:
+    metadata:
+      labels:
+        app: bookie
+      ownerReferences:
+      - apiVersion: apps/v1
+        kind: StatefulSet
+        name: bookie
+        uid: "1234"
+    spec:
+      containers:
+      - name: bookie
+        image: apachepulsar/pulsar
+        imagePullPolicy: IfNotPresent
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: broker-config
+data:
+  clusterName: pulsar
+---
+pod:
+  name: proxy
+  containers:
+  - name: proxy
+    image: apachepulsar/pulsar
+`
+
+func TestBundle(t *testing.T) {
+	objects, err := bundle.Read("bundle.yaml", strings.NewReader(testBundle))
+	if err != nil {
+		t.Fatalf("unexpected error reading bundle: %v", err)
+	}
+
+	findings, err := Bundle(&bundle.Bundle{Objects: objects})
+	if err != nil {
+		t.Fatalf("unexpected error round-tripping bundle: %v", err)
+	}
+
+	var results []string
+	for _, finding := range findings {
+		results = append(results, finding.Object+" "+finding.Path+" "+finding.Rule)
+	}
+
+	// Fields mantle doesn't model are kept in extra, so nothing is lost.
+	if len(results) > 0 {
+		t.Errorf("expected no findings, got %v", results)
+	}
+}
+
+func TestUnsupported(t *testing.T) {
+	objects, err := bundle.Read("bundle.yaml", strings.NewReader(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: broker
+spec:
+  tolerations:
+  - key: dedicated
+    operator: Exists
+    effect: NoScheduleSometimes
+  containers:
+  - name: broker
+    image: apachepulsar/pulsar
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: bookie
+spec:
+  template:
+    spec:
+      tolerations:
+      - key: dedicated
+        operator: Exists
+        effect: NoScheduleSometimes
+      containers:
+      - name: bookie
+        image: apachepulsar/pulsar
+`))
+	if err != nil {
+		t.Fatalf("unexpected error reading bundle: %v", err)
+	}
+
+	findings, err := Bundle(&bundle.Bundle{Objects: objects})
+	if err != nil {
+		t.Fatalf("unexpected error round-tripping bundle: %v", err)
+	}
+
+	var results []string
+	for _, finding := range findings {
+		results = append(results, finding.Object+" "+finding.Path+" "+finding.Rule)
+		if !strings.Contains(finding.Message, "NoScheduleSometimes") {
+			t.Errorf("expected the unrecognized effect in %q", finding.Message)
+		}
+	}
+
+	expected := []string{
+		"Pod/broker  unsupported",
+		"DaemonSet/bookie spec.template unsupported",
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected %v, got %v", expected, results)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	before := map[string]interface{}{
+		"a": "x",
+		"b": []interface{}{"1", "2"},
+		"c": map[string]interface{}{},
+		"d": false,
+	}
+	after := map[string]interface{}{
+		"a": "y",
+		"b": []interface{}{"1"},
+		"e": "z",
+	}
+
+	var findings []report.Finding
+	compare("", before, after, &findings)
+
+	var results []string
+	for _, finding := range findings {
+		results = append(results, finding.Path+" "+finding.Message)
+	}
+
+	expected := []string{
+		`a changed from "x" to "y"`,
+		`b[1] dropped "2"`,
+		`d dropped false`,
+		`e added "z"`,
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("expected %v, got %v", expected, results)
+	}
+}
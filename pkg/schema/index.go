@@ -0,0 +1,174 @@
+package schema
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Index answers questions about the values at paths of a document
+// that a schema describes
+type Index struct {
+	root Schema
+}
+
+// NewIndex returns an index of the schema, e.g. one returned by Generate
+func NewIndex(root Schema) *Index {
+	return &Index{root: root}
+}
+
+// At returns the schemas the value at path may match: more than one
+// for shorthands, which have string and object forms.
+func (x *Index) At(path []string) []Schema {
+	current := []Schema{x.root}
+	for _, segment := range path {
+		var next []Schema
+		for _, s := range current {
+			for _, alt := range x.alternatives(s) {
+				if child, ok := x.child(alt, segment); ok {
+					next = append(next, child)
+				}
+			}
+		}
+		if len(next) == 0 {
+			return nil
+		}
+		current = next
+	}
+
+	var resolved []Schema
+	for _, s := range current {
+		resolved = append(resolved, x.alternatives(s)...)
+	}
+	return resolved
+}
+
+// alternatives dereferences s and expands its oneOf
+func (x *Index) alternatives(s Schema) []Schema {
+	s = x.deref(s)
+
+	var alts []Schema
+	switch oneOf := s["oneOf"].(type) {
+	case []Schema:
+		for _, alt := range oneOf {
+			alts = append(alts, x.deref(alt))
+		}
+	case []interface{}:
+		for _, alt := range oneOf {
+			if alt, ok := alt.(Schema); ok {
+				alts = append(alts, x.deref(alt))
+			}
+		}
+	default:
+		alts = append(alts, s)
+	}
+
+	return alts
+}
+
+func (x *Index) deref(s Schema) Schema {
+	ref, ok := s["$ref"].(string)
+	if !ok {
+		return s
+	}
+
+	definitions, _ := x.root["definitions"].(Schema)
+	if def, ok := definitions[strings.TrimPrefix(ref, "#/definitions/")].(Schema); ok {
+		return def
+	}
+	return s
+}
+
+func (x *Index) child(s Schema, segment string) (Schema, bool) {
+	if properties, ok := s["properties"].(Schema); ok {
+		if child, ok := properties[segment].(Schema); ok {
+			return child, true
+		}
+	}
+
+	if items, ok := s["items"].(Schema); ok && isIndex(segment) {
+		return items, true
+	}
+
+	if additional, ok := s["additionalProperties"].(Schema); ok {
+		return additional, true
+	}
+
+	return nil, false
+}
+
+// Properties returns the sorted property names of the schemas
+func (x *Index) Properties(schemas []Schema) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, s := range schemas {
+		properties, _ := s["properties"].(Schema)
+		for name := range properties {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Values returns the values a scalar matching the schemas may take,
+// if there's a fixed set of them
+func (x *Index) Values(schemas []Schema) []string {
+	var values []string
+	for _, s := range schemas {
+		switch enum := s["enum"].(type) {
+		case []string:
+			values = append(values, enum...)
+		case []interface{}:
+			for _, value := range enum {
+				if value, ok := value.(string); ok {
+					values = append(values, value)
+				}
+			}
+		}
+		if s["type"] == "boolean" {
+			values = append(values, "true", "false")
+		}
+	}
+	return values
+}
+
+// Forms returns the descriptions of the schemas, which explain the
+// grammar of shorthand forms, e.g. "user:role:type:level"
+func (x *Index) Forms(schemas []Schema) []string {
+	var forms []string
+	for _, s := range schemas {
+		if description, ok := s["description"].(string); ok {
+			forms = append(forms, description)
+		}
+	}
+	return forms
+}
+
+// Describe summarizes the type of the schemas, e.g. "string | object"
+func (x *Index) Describe(schemas []Schema) string {
+	var types []string
+	for _, s := range schemas {
+		switch t := s["type"].(type) {
+		case string:
+			types = append(types, t)
+		case []string:
+			types = append(types, t...)
+		}
+	}
+	return strings.Join(types, " | ")
+}
+
+// isIndex reports whether a path segment is a sequence index or a
+// wildcard standing for any index
+func isIndex(segment string) bool {
+	if segment == "*" {
+		return true
+	}
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
@@ -0,0 +1,207 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+
+	"mantle/pkg/codec"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+// Draft07 identifies the version of JSON Schema that mantle schemas use
+const Draft07 = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document, or a subschema of one
+type Schema map[string]interface{}
+
+// enum is implemented by the int enums that are written as strings
+type enum interface {
+	EnumNames() []string
+}
+
+var enumType = reflect.TypeOf((*enum)(nil)).Elem()
+
+// Generate returns the schema of a mantle document: an envelope
+// with a single key, the kind, holding a mantle object of that kind.
+func Generate() (Schema, error) {
+	g := newGenerator()
+
+	var kinds []interface{}
+	for _, kind := range codec.Kinds {
+		obj, err := codec.NewMantleObject(kind)
+		if err != nil {
+			return nil, err
+		}
+
+		kinds = append(kinds, Schema{
+			"type":                 "object",
+			"properties":           Schema{kind: g.schema(reflect.TypeOf(obj))},
+			"required":             []string{kind},
+			"additionalProperties": false,
+		})
+	}
+
+	return Schema{
+		"$schema":     Draft07,
+		"title":       "mantle",
+		"oneOf":       kinds,
+		"definitions": g.definitions,
+	}, nil
+}
+
+// GenerateKind returns the schema of a mantle object of the given
+// kind, without the envelope
+func GenerateKind(kind string) (Schema, error) {
+	obj, err := codec.NewMantleObject(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newGenerator()
+	s := g.schema(reflect.TypeOf(obj))
+	s["$schema"] = Draft07
+	s["title"] = kind
+	s["definitions"] = g.definitions
+	return s, nil
+}
+
+// Marshal writes a schema as indented JSON
+func Marshal(s Schema) ([]byte, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, serrors.ContextualizeErrorf(err, "schema")
+	}
+	return data, nil
+}
+
+type generator struct {
+	definitions Schema
+}
+
+func newGenerator() *generator {
+	return &generator{definitions: Schema{}}
+}
+
+func definitionName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	return pkg[strings.LastIndex(pkg, "/")+1:] + "." + t.Name()
+}
+
+func (g *generator) schema(t reflect.Type) Schema {
+	if t.Kind() == reflect.Ptr {
+		return g.schema(t.Elem())
+	}
+
+	if s, ok := shorthandSchema(t); ok {
+		return s
+	}
+
+	if t.Implements(enumType) {
+		names := reflect.Zero(t).Interface().(enum).EnumNames()
+		return Schema{"type": "string", "enum": names}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return g.structRef(t)
+	case reflect.Map:
+		s := Schema{
+			"type":                 "object",
+			"additionalProperties": g.schema(t.Elem()),
+		}
+		if t.Key().Implements(enumType) {
+			s["propertyNames"] = g.schema(t.Key())
+		}
+		return s
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "contentEncoding": "base64"}
+		}
+		return Schema{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	default:
+		return Schema{}
+	}
+}
+
+// structRef adds the struct to the definitions, if it isn't
+// already there, and returns a reference to it
+func (g *generator) structRef(t reflect.Type) Schema {
+	name := definitionName(t)
+	ref := Schema{"$ref": "#/definitions/" + name}
+	if _, ok := g.definitions[name]; ok {
+		return ref
+	}
+
+	// Reserve the name first, since the struct may refer to itself.
+	g.definitions[name] = Schema{}
+	properties := Schema{}
+	g.addProperties(t, properties)
+	g.definitions[name] = Schema{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	return ref
+}
+
+func (g *generator) addProperties(t reflect.Type, properties Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, inline, ok := jsonField(field)
+		if !ok {
+			continue
+		}
+
+		if inline {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			g.addProperties(fieldType, properties)
+			continue
+		}
+
+		properties[name] = g.schema(field.Type)
+	}
+}
+
+// jsonField returns the name a field is written as, or whether its
+// fields are written inline. It returns false for fields that
+// aren't written at all.
+func jsonField(field reflect.StructField) (string, bool, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	for _, option := range parts[1:] {
+		if option == "inline" {
+			return "", true, true
+		}
+	}
+
+	if field.Anonymous && len(name) == 0 && field.Type.Kind() == reflect.Struct {
+		return "", true, true
+	}
+	if len(field.PkgPath) > 0 {
+		return "", false, false
+	}
+	if len(name) == 0 {
+		name = field.Name
+	}
+
+	return name, false, true
+}
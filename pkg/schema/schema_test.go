@@ -0,0 +1,184 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"mantle/internal/yaml"
+	"mantle/pkg/codec"
+
+	"github.com/koki/json"
+)
+
+const testDocument = `
+pod:
+  name: broker
+  labels:
+    app: broker
+  restart_policy: on-failure
+  host_mode: [net]
+  sysctls: [net.core.somaxconn=1024]
+  selinux: system_u:system_r:container_t:s0
+  affinity:
+    node:
+      hard:
+      - expression:
+        - key: zone
+          op: in
+          values: [a]
+  containers:
+  - name: broker
+    image: apachepulsar/pulsar:2.1
+    pull: if-not-present
+    cpu: 500m-2
+    mem: 2Gi
+    on_start: http://:8080/start
+    liveness_probe: http://:8080/health delay=10
+    readiness_probe:
+      exec: [bin/pulsar-admin, brokers, healthcheck]
+      timeout: 5
+`
+
+// check is a small JSON Schema validator, covering the keywords
+// that Generate uses
+func check(root, s Schema, value interface{}, path string) error {
+	if ref, ok := s["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		return check(root, root["definitions"].(Schema)[name].(Schema), value, path)
+	}
+
+	if oneOf, ok := s["oneOf"]; ok {
+		var schemas []Schema
+		switch oneOf := oneOf.(type) {
+		case []Schema:
+			schemas = oneOf
+		case []interface{}:
+			for _, sub := range oneOf {
+				schemas = append(schemas, sub.(Schema))
+			}
+		}
+		for _, sub := range schemas {
+			if check(root, sub, value, path) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: %v matches none of oneOf", path, value)
+	}
+
+	if enum, ok := s["enum"].([]string); ok {
+		for _, name := range enum {
+			if value == name {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: %v isn't one of %v", path, value, enum)
+	}
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		properties, _ := s["properties"].(Schema)
+		for key, v := range value {
+			sub, ok := properties[key].(Schema)
+			if !ok {
+				sub, ok = s["additionalProperties"].(Schema)
+			}
+			if !ok {
+				if s["additionalProperties"] == false || properties != nil {
+					return fmt.Errorf("%s: unexpected property %s", path, key)
+				}
+				continue
+			}
+			if err := check(root, sub, v, path+"."+key); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		items, ok := s["items"].(Schema)
+		if !ok {
+			return fmt.Errorf("%s: unexpected array", path)
+		}
+		for i, v := range value {
+			if err := check(root, items, v, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case string:
+		if t, ok := s["type"]; ok && t != "string" && !strings.Contains(fmt.Sprint(t), "string") {
+			return fmt.Errorf("%s: unexpected string %q", path, value)
+		}
+	}
+
+	return nil
+}
+
+func TestGenerateMatchesEncoding(t *testing.T) {
+	s, err := Generate()
+	if err != nil {
+		t.Fatalf("unexpected error generating schema: %v", err)
+	}
+	if s["$schema"] != Draft07 {
+		t.Errorf("expected a draft-07 schema, got %v", s["$schema"])
+	}
+
+	// Decode and re-encode the document, so that the schema is
+	// checked against what mantle writes as well as what it reads.
+	doc := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(testDocument), &doc); err != nil {
+		t.Fatalf("unexpected error reading document: %v", err)
+	}
+	mantleObj, err := codec.ParseMantleType(doc)
+	if err != nil {
+		t.Fatalf("unexpected error parsing document: %v", err)
+	}
+	wrapped, err := codec.WrapMantleType(mantleObj)
+	if err != nil {
+		t.Fatalf("unexpected error wrapping document: %v", err)
+	}
+	data, err := json.Marshal(wrapped)
+	if err != nil {
+		t.Fatalf("unexpected error encoding document: %v", err)
+	}
+
+	for name, document := range map[string][]byte{"written": data, "read": mustJSON(t, doc)} {
+		generic := map[string]interface{}{}
+		if err := json.Unmarshal(document, &generic); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := check(s, s, generic, "$"); err != nil {
+			t.Errorf("%s document doesn't match the schema: %v", name, err)
+		}
+	}
+
+	invalid := map[string]interface{}{"pod": map[string]interface{}{"restart_policy": "sometimes"}}
+	if err := check(s, s, invalid, "$"); err == nil {
+		t.Errorf("expected an invalid restart_policy to fail the schema")
+	}
+
+	unknownKind := map[string]interface{}{"deployment": map[string]interface{}{}}
+	if err := check(s, s, unknownKind, "$"); err == nil {
+		t.Errorf("expected an unknown kind to fail the schema")
+	}
+}
+
+func mustJSON(t *testing.T, obj interface{}) []byte {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return data
+}
+
+func TestGenerateKind(t *testing.T) {
+	s, err := GenerateKind(codec.KindConfigMap)
+	if err != nil {
+		t.Fatalf("unexpected error generating schema: %v", err)
+	}
+	if s["$ref"] != "#/definitions/configmap.ConfigMap" {
+		t.Errorf("expected a reference to the config map definition, got %v", s["$ref"])
+	}
+
+	if _, err := GenerateKind("deployment"); err == nil {
+		t.Errorf("expected an error for an unknown kind")
+	}
+}
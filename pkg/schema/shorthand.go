@@ -0,0 +1,155 @@
+package schema
+
+import (
+	"reflect"
+
+	"mantle/pkg/core/action"
+	"mantle/pkg/core/meta"
+	"mantle/pkg/core/pod/container/probe"
+	"mantle/pkg/core/pod/container/resources"
+	"mantle/pkg/core/pod/podtemplate"
+	"mantle/pkg/core/selinux"
+	"mantle/pkg/util/floatstr"
+	"mantle/pkg/util/intbool"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// The schemas of types that are written in shorthand forms (see their
+// MarshalJSON and UnmarshalJSON methods) rather than field by field.
+
+var stringArray = Schema{"type": "array", "items": Schema{"type": "string"}}
+
+var quantity = Schema{"type": []string{"string", "number"}}
+
+var actionURL = Schema{
+	"type":        "string",
+	"pattern":     "^(?i)(http|https|tcp)://",
+	"description": "http://host:port/path, https://host:port/path or tcp://host:port",
+}
+
+var actionFields = Schema{
+	"exec":    stringArray,
+	"net":     actionURL,
+	"headers": stringArray,
+}
+
+var rangeSchema = Schema{
+	"oneOf": []Schema{
+		{"type": "string", "description": "min-max, min-, -max, or a single quantity for both"},
+		{"type": "number"},
+		{
+			"type": "object",
+			"properties": Schema{
+				"min": quantity,
+				"max": quantity,
+			},
+			"additionalProperties": false,
+		},
+	},
+}
+
+func probeSchema() Schema {
+	properties := Schema{
+		"delay":             Schema{"type": "integer"},
+		"interval":          Schema{"type": "integer"},
+		"min_count_success": Schema{"type": "integer"},
+		"min_count_fail":    Schema{"type": "integer"},
+		"timeout":           Schema{"type": "integer"},
+	}
+	for name, s := range actionFields {
+		properties[name] = s
+	}
+
+	return Schema{
+		"oneOf": []Schema{
+			{
+				"type":        "string",
+				"pattern":     "^(?i)(http|https|tcp)://",
+				"description": "a URL, optionally followed by delay=, interval= and timeout= in seconds",
+			},
+			{
+				"type":                 "object",
+				"properties":           properties,
+				"additionalProperties": false,
+			},
+		},
+	}
+}
+
+func shorthandSchema(t reflect.Type) (Schema, bool) {
+	switch t {
+	case reflect.TypeOf(action.Action{}):
+		return Schema{
+			"oneOf": []Schema{
+				actionURL,
+				stringArray,
+				{
+					"type":                 "object",
+					"properties":           actionFields,
+					"additionalProperties": false,
+				},
+			},
+		}, true
+	case reflect.TypeOf(probe.Probe{}):
+		return probeSchema(), true
+	case reflect.TypeOf(resources.CPU{}), reflect.TypeOf(resources.Mem{}),
+		reflect.TypeOf(resources.Storage{}), reflect.TypeOf(resources.Extended{}):
+		return rangeSchema, true
+	case reflect.TypeOf(selinux.SELinux{}):
+		return Schema{
+			"oneOf": []Schema{
+				{"type": "string", "pattern": "^[^:]*:[^:]*:[^:]*:.*$", "description": "user:role:type:level"},
+				{
+					"type": "object",
+					"properties": Schema{
+						"user":  Schema{"type": "string"},
+						"role":  Schema{"type": "string"},
+						"type":  Schema{"type": "string"},
+						"level": Schema{"type": "string"},
+					},
+					"additionalProperties": false,
+				},
+			},
+		}, true
+	case reflect.TypeOf(meta.OwnerRef{}):
+		return Schema{
+			"oneOf": []Schema{
+				{"type": "string", "pattern": "^[^/\\s]+/[^/\\s]+( controller)?$", "description": "kind/name, optionally followed by controller"},
+				{
+					"type": "object",
+					"properties": Schema{
+						"version":        Schema{"type": "string"},
+						"kind":           Schema{"type": "string"},
+						"name":           Schema{"type": "string"},
+						"uid":            Schema{"type": "string"},
+						"controller":     Schema{"type": "boolean"},
+						"block_deletion": Schema{"type": "boolean"},
+					},
+					"additionalProperties": false,
+				},
+			},
+		}, true
+	case reflect.TypeOf(podtemplate.PodConditionType("")):
+		return Schema{
+			"type":        "string",
+			"description": "scheduled, ready, initialized, unschedulable, containers-ready, or any other condition type",
+		}, true
+	case reflect.TypeOf(podtemplate.Sysctl{}):
+		return Schema{"type": "string", "pattern": "^[^=]+=", "description": "name=value"}, true
+	case reflect.TypeOf(floatstr.FloatOrString{}):
+		return Schema{"type": []string{"string", "number"}}, true
+	case reflect.TypeOf(intbool.IntOrBool{}):
+		return Schema{"type": []string{"integer", "boolean"}}, true
+	case reflect.TypeOf(intstr.IntOrString{}):
+		return Schema{"type": []string{"string", "integer"}}, true
+	case reflect.TypeOf(resource.Quantity{}):
+		return quantity, true
+	case reflect.TypeOf(metav1.Time{}):
+		return Schema{"type": "string", "format": "date-time"}, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,66 @@
+package sourcemap
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// e.g. The Pod "broker" is invalid: ...
+	invalidObjectRegexp = regexp.MustCompile(`\b([A-Z][A-Za-z]*) "([^"]+)" is invalid`)
+	// e.g. error validating data: ValidationError(Pod.spec.containers[0]): ...
+	validationErrorRegexp = regexp.MustCompile(`ValidationError\(([A-Z][A-Za-z]*)\.`)
+	// Kubernetes field paths, e.g. spec.containers[0].resources.limits.cpu
+	kubePathRegexp = regexp.MustCompile(`\b(?:metadata|spec|data|binaryData)(?:\.[A-Za-z0-9_-]+|\[[^\]\s]*\])*`)
+)
+
+// Rewrite replaces the kubernetes field paths in error text, e.g. from
+// kubectl or the API server, with the mantle fields and lines they came
+// from. Errors naming the object they're about (e.g. The Pod "broker"
+// is invalid) are looked up in that object's source map, and left as
+// they are if the object isn't in the bundle; other paths are looked up
+// in the first object that maps them. Paths that aren't in the source
+// map are left as they are.
+func (m *SourceMap) Rewrite(text string) string {
+	lines := strings.Split(text, "\n")
+
+	var current *Object
+	var currentKind string
+	known := true
+	for i, line := range lines {
+		if match := invalidObjectRegexp.FindStringSubmatch(line); match != nil {
+			current, known = m.Find(match[1], match[2])
+			currentKind = match[1]
+		} else if match := validationErrorRegexp.FindStringSubmatch(line); match != nil {
+			current, currentKind, known = nil, match[1], true
+		}
+		if !known {
+			continue
+		}
+
+		lines[i] = kubePathRegexp.ReplaceAllStringFunc(line, func(path string) string {
+			if entry, ok := m.lookup(current, currentKind, path); ok {
+				return entry.String()
+			}
+			return path
+		})
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *SourceMap) lookup(current *Object, kind, path string) (Entry, bool) {
+	if current != nil {
+		return current.Lookup(path)
+	}
+
+	for _, o := range m.Objects {
+		if len(kind) > 0 && !strings.EqualFold(o.Kind, kind) {
+			continue
+		}
+		if entry, ok := o.Lookup(path); ok {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
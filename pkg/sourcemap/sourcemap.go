@@ -0,0 +1,454 @@
+// Package sourcemap links the fields of the kubernetes objects mantle
+// generates back to the mantle fields, and the lines of the mantle
+// files, they came from. It's how errors from kubectl or the API server,
+// which talk about fields the author never wrote, are explained in
+// mantle terms.
+package sourcemap
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mantle/internal/yaml"
+	"mantle/pkg/bundle"
+	"mantle/pkg/codec"
+	"mantle/pkg/fieldmap"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Entry links a kubernetes field to the mantle field it was converted from
+type Entry struct {
+	// Kube is the path of the kubernetes field,
+	// e.g. spec.containers[0].resources.limits.cpu
+	Kube string
+	// Mantle is the path of the mantle field, e.g. pod.containers.0.cpu.max
+	Mantle string
+	// File and Position locate the mantle field. If the field isn't
+	// written in the file, e.g. it was defaulted, they locate its
+	// closest enclosing field that is.
+	File string
+	yaml.Position
+}
+
+// String formats the mantle side of the entry,
+// e.g. pod.containers[0].cpu.max (broker.yaml:12:7)
+func (e Entry) String() string {
+	if e.Line == 0 {
+		return FormatPath(e.Mantle)
+	}
+	return fmt.Sprintf("%s (%s:%s)", FormatPath(e.Mantle), e.File, e.Position)
+}
+
+// Object is the source map of a single generated kubernetes object
+type Object struct {
+	Kind      string
+	Name      string
+	Namespace string
+
+	entries map[string]Entry
+}
+
+// SourceMap holds the source maps of the objects generated from a bundle
+type SourceMap struct {
+	Objects []*Object
+}
+
+// ToKube converts every mantle object of the bundle to kubernetes,
+// recording where each field of the generated objects came from.
+// Kubernetes objects in the bundle are skipped.
+func ToKube(b *bundle.Bundle) ([]runtime.Object, *SourceMap, error) {
+	var kubeObjs []runtime.Object
+	m := &SourceMap{}
+
+	for _, object := range b.Objects {
+		if object.Mantle == nil {
+			continue
+		}
+
+		kubeObj, err := codec.ToKube(object.Mantle)
+		if err != nil {
+			return nil, nil, object.Locate(err)
+		}
+
+		objectMap, err := record(object, kubeObj)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		kubeObjs = append(kubeObjs, kubeObj)
+		m.Objects = append(m.Objects, objectMap)
+	}
+
+	return kubeObjs, m, nil
+}
+
+// record maps every field of the generated object, and every list and
+// object holding fields, to the mantle field it came from.
+func record(object *bundle.Object, kubeObj runtime.Object) (*Object, error) {
+	o := &Object{
+		Kind:    kubeObj.GetObjectKind().GroupVersionKind().Kind,
+		entries: map[string]Entry{},
+	}
+	if accessor, err := meta.Accessor(kubeObj); err == nil {
+		o.Name = accessor.GetName()
+		o.Namespace = accessor.GetNamespace()
+	}
+
+	data, err := json.Marshal(kubeObj)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	wrapped, err := codec.WrapMantleType(object.Mantle)
+	if err != nil {
+		return nil, err
+	}
+	var kind string
+	for k := range wrapped {
+		kind = k
+	}
+
+	walk(tree, nil, func(path []node) {
+		candidates, length := mantleFields(kind, path)
+		if length < len(path) {
+			if items := o.listItem(path); len(items) > 0 {
+				candidates = items
+			}
+		}
+		if len(candidates) == 0 {
+			return
+		}
+
+		// Mantle fields can share a kubernetes field, e.g. rw and ro;
+		// prefer the one whose closest written field is the deepest.
+		entry := Entry{Kube: formatNodes(path), File: object.Source}
+		bestDepth := -1
+		for _, candidate := range candidates {
+			if depth := writtenDepth(object, candidate); depth > bestDepth {
+				entry.Mantle, bestDepth = candidate, depth
+			}
+		}
+		entry.Position, _ = object.Position(entry.Mantle)
+		o.entries[entry.Kube] = entry
+	})
+
+	return o, nil
+}
+
+// listItem maps an item of a list that has no field of its own, e.g.
+// spec.containers[0], to the matching item of the mantle list.
+func (o *Object) listItem(path []node) []string {
+	last := path[len(path)-1]
+	if !last.isIndex() || len(path) < 2 {
+		return nil
+	}
+
+	listPattern := formatPattern(path[:len(path)-1])
+	if reorderedLists[listPattern] {
+		return nil
+	}
+	if _, ok := keyedLists[listPattern]; ok {
+		return nil
+	}
+
+	parent, ok := o.entries[formatNodes(path[:len(path)-1])]
+	if !ok {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s.%d", parent.Mantle, last.index)}
+}
+
+// writtenDepth counts the segments of the closest enclosing path of
+// mantlePath, or mantlePath itself, that's written in the file.
+func writtenDepth(object *bundle.Object, mantlePath string) int {
+	segments := strings.Split(mantlePath, ".")
+	for depth := len(segments); depth > 0; depth-- {
+		if _, exact := object.Position(strings.Join(segments[:depth], ".")); exact {
+			return depth
+		}
+	}
+	return 0
+}
+
+// node is a step in a path through a kubernetes object: a key, or an
+// index along with the list item it selects
+type node struct {
+	key   string
+	index int
+	item  interface{}
+}
+
+func (n node) isIndex() bool {
+	return len(n.key) == 0
+}
+
+func walk(value interface{}, path []node, visit func([]node)) {
+	if len(path) > 0 {
+		visit(path)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			walk(v[key], appendNode(path, node{key: key}), visit)
+		}
+	case []interface{}:
+		for i, item := range v {
+			walk(item, appendNode(path, node{index: i, item: item}), visit)
+		}
+	}
+}
+
+func appendNode(path []node, n node) []node {
+	p := make([]node, len(path), len(path)+1)
+	copy(p, path)
+	return append(p, n)
+}
+
+// keyedLists are kubernetes lists that mantle writes as maps, keyed by
+// a field of their items
+var keyedLists = map[string]string{
+	"spec.volumes": "name",
+}
+
+// reorderedLists are kubernetes lists whose items aren't in the order
+// of the mantle list they're converted from, e.g. env, which mantle
+// splits into env and envFrom. Their items map to the mantle list as
+// a whole.
+var reorderedLists = map[string]bool{
+	"spec.containers[*].env":         true,
+	"spec.containers[*].envFrom":     true,
+	"spec.initContainers[*].env":     true,
+	"spec.initContainers[*].envFrom": true,
+}
+
+// mantleFields finds the field map entries whose kubernetes field
+// matches the most of the path, and fills their wildcards from the path.
+// The most specific mantle fields come first, and the length of the
+// matched part of the path is returned along with them.
+func mantleFields(kind string, path []node) ([]string, int) {
+	var best [][]string
+	bestLength := 0
+
+	for _, field := range fieldmap.Fields() {
+		if !strings.HasPrefix(field.Path, kind+".") {
+			continue
+		}
+
+		for _, kube := range field.Kube {
+			pattern := parsePattern(kube)
+			if len(pattern) == 0 || len(pattern) > len(path) || len(pattern) < bestLength {
+				continue
+			}
+
+			mantlePath, ok := bind(field.Path, pattern, path)
+			if !ok {
+				continue
+			}
+
+			if len(pattern) > bestLength {
+				best, bestLength = nil, len(pattern)
+			}
+			best = append(best, mantlePath)
+		}
+	}
+
+	sort.SliceStable(best, func(i, j int) bool {
+		return len(best[i]) > len(best[j])
+	})
+
+	var paths []string
+	seen := map[string]bool{}
+	for _, mantlePath := range best {
+		joined := strings.Join(mantlePath, ".")
+		if !seen[joined] {
+			seen[joined] = true
+			paths = append(paths, joined)
+		}
+	}
+	return paths, bestLength
+}
+
+// parsePattern splits a kubernetes field path into keys and [*]
+// wildcards, e.g. spec.containers[*].image into spec, containers, *,
+// image. Keys may be written in brackets, e.g. annotations[name]. It
+// returns nil for paths with placeholders, like <name>.
+func parsePattern(kube string) []string {
+	if strings.Contains(kube, "<") {
+		return nil
+	}
+
+	var pattern []string
+	for len(kube) > 0 {
+		var segment string
+		if strings.HasPrefix(kube, "[") {
+			end := strings.Index(kube, "]")
+			if end < 0 {
+				return nil
+			}
+			segment, kube = kube[1:end], kube[end+1:]
+		} else {
+			end := strings.IndexAny(kube, ".[")
+			if end < 0 {
+				end = len(kube)
+			}
+			segment, kube = kube[:end], kube[end:]
+		}
+		kube = strings.TrimPrefix(kube, ".")
+		pattern = append(pattern, segment)
+	}
+
+	return pattern
+}
+
+// bind matches the pattern against the start of the path, and fills the
+// wildcards of the mantle path with the indices or keys they matched.
+func bind(mantlePath string, pattern []string, path []node) ([]string, bool) {
+	var captured []string
+	var prefix []string
+	for i, segment := range pattern {
+		n := path[i]
+		switch {
+		case segment == fieldmap.Wildcard:
+			listPath := strings.Join(prefix, ".")
+			if reorderedLists[listPath] {
+				captured = append(captured, "")
+			} else if key, ok := keyedLists[listPath]; ok {
+				item, _ := n.item.(map[string]interface{})
+				name, _ := item[key].(string)
+				captured = append(captured, name)
+			} else if n.isIndex() {
+				captured = append(captured, fmt.Sprint(n.index))
+			} else {
+				captured = append(captured, n.key)
+			}
+			prefix = append(prefix[:len(prefix)-1], prefix[len(prefix)-1]+"[*]")
+		case segment == n.key:
+			prefix = append(prefix, segment)
+		default:
+			return nil, false
+		}
+	}
+
+	var result []string
+	for _, segment := range strings.Split(mantlePath, ".") {
+		if segment != fieldmap.Wildcard {
+			result = append(result, segment)
+			continue
+		}
+		if len(captured) == 0 || len(captured[0]) == 0 {
+			// The item can't be told apart, so stop at the list.
+			return result, true
+		}
+		result = append(result, captured[0])
+		captured = captured[1:]
+	}
+
+	return result, true
+}
+
+// formatNodes formats a path through a kubernetes object the way
+// kubernetes errors do, e.g. spec.containers[0].image. Keys that aren't
+// plain names, like annotation keys, are written in brackets.
+func formatNodes(path []node) string {
+	var b strings.Builder
+	for _, n := range path {
+		switch {
+		case n.isIndex():
+			fmt.Fprintf(&b, "[%d]", n.index)
+		case strings.ContainsAny(n.key, "./"):
+			fmt.Fprintf(&b, "[%s]", n.key)
+		default:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(n.key)
+		}
+	}
+	return b.String()
+}
+
+// formatPattern formats a path through a kubernetes object with its
+// indices as wildcards, e.g. spec.containers[*].env
+func formatPattern(path []node) string {
+	var b strings.Builder
+	for _, n := range path {
+		if n.isIndex() {
+			b.WriteString("[*]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(n.key)
+	}
+	return b.String()
+}
+
+// FormatPath writes the sequence indices of a mantle path in brackets,
+// e.g. pod.containers[0].cpu.max
+func FormatPath(path string) string {
+	var b strings.Builder
+	for i, segment := range strings.Split(path, ".") {
+		if _, err := strconv.Atoi(segment); err == nil && i > 0 {
+			fmt.Fprintf(&b, "[%s]", segment)
+			continue
+		}
+		if i > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
+
+// Lookup returns the entry of the kubernetes field, or of its closest
+// enclosing field that has one. Paths of pod templates, i.e. with a
+// spec.template. prefix, are looked up as paths of the pod.
+func (o *Object) Lookup(kubePath string) (Entry, bool) {
+	kubePath = strings.TrimPrefix(kubePath, "spec.template.")
+	for len(kubePath) > 0 {
+		if entry, ok := o.entries[kubePath]; ok {
+			return entry, true
+		}
+		kubePath = parentPath(kubePath)
+	}
+	return Entry{}, false
+}
+
+// parentPath drops the last key or index of a kubernetes field path
+func parentPath(path string) string {
+	if strings.HasSuffix(path, "]") {
+		if i := strings.LastIndex(path, "["); i >= 0 {
+			return path[:i]
+		}
+	}
+	if i := strings.LastIndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// Find returns the source map of the object of the given kind and name
+func (m *SourceMap) Find(kind, name string) (*Object, bool) {
+	for _, o := range m.Objects {
+		if strings.EqualFold(o.Kind, kind) && o.Name == name {
+			return o, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,90 @@
+package sourcemap
+
+import (
+	"strings"
+	"testing"
+
+	"mantle/pkg/bundle"
+)
+
+const testBundle = `pod:
+  name: broker
+  annotations:
+    team: streaming
+  volumes:
+    data:
+      EmptyDir: {}
+  containers:
+  - name: broker
+    image: apachepulsar/pulsar
+    cpu: 500m-2
+    ro: true
+    env:
+    - Type: val
+      Val:
+        Key: PULSAR_MEM
+        Val: 2g
+    volume:
+    - mount: /data
+      store: data
+`
+
+func testSourceMap(t *testing.T) *SourceMap {
+	objects, err := bundle.Read("broker.yaml", strings.NewReader(testBundle))
+	if err != nil {
+		t.Fatalf("unexpected error reading bundle: %v", err)
+	}
+
+	_, m, err := ToKube(&bundle.Bundle{Objects: objects})
+	if err != nil {
+		t.Fatalf("unexpected error converting bundle: %v", err)
+	}
+	return m
+}
+
+func TestLookup(t *testing.T) {
+	m := testSourceMap(t)
+	pod, ok := m.Find("Pod", "broker")
+	if !ok {
+		t.Fatalf("expected a source map for Pod/broker")
+	}
+
+	testCases := []struct {
+		kube     string
+		expected string
+	}{
+		{"spec.containers[0].resources.limits.cpu", "pod.containers[0].cpu.max (broker.yaml:11:5)"},
+		{"spec.containers[0].resources.requests.cpu", "pod.containers[0].cpu.min (broker.yaml:11:5)"},
+		{"spec.template.spec.containers[0].image", "pod.containers[0].image (broker.yaml:10:5)"},
+		{"spec.containers[0].securityContext.readOnlyRootFilesystem", "pod.containers[0].ro (broker.yaml:12:5)"},
+		{"spec.containers[0].volumeMounts[0].name", "pod.containers[0].volume[0].store (broker.yaml:20:7)"},
+		{"spec.volumes[0].emptyDir", "pod.volumes.data.EmptyDir (broker.yaml:7:7)"},
+		{"spec.containers[0].env[0].value", "pod.containers[0].env (broker.yaml:13:5)"},
+		{"metadata.annotations.team", "pod.annotations.team (broker.yaml:4:5)"},
+		// Fields that weren't generated map to their closest parent
+		{"spec.containers[0].livenessProbe", "pod.containers[0] (broker.yaml:9:5)"},
+	}
+
+	for _, testCase := range testCases {
+		entry, ok := pod.Lookup(testCase.kube)
+		if !ok || entry.String() != testCase.expected {
+			t.Errorf("%s: expected %s, got %s", testCase.kube, testCase.expected, entry)
+		}
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	m := testSourceMap(t)
+
+	text := `The Pod "broker" is invalid: spec.containers[0].resources.limits.cpu: Invalid value: "2": must be greater than or equal to cpu request
+error validating data: ValidationError(Pod.spec.containers[0]): unknown field "colour" in io.k8s.api.core.v1.Container
+The Pod "other" is invalid: spec.containers[0].image: Required value`
+
+	expected := `The Pod "broker" is invalid: pod.containers[0].cpu.max (broker.yaml:11:5): Invalid value: "2": must be greater than or equal to cpu request
+error validating data: ValidationError(Pod.pod.containers[0] (broker.yaml:9:5)): unknown field "colour" in io.k8s.api.core.v1.Container
+The Pod "other" is invalid: spec.containers[0].image: Required value`
+
+	if result := m.Rewrite(text); result != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, result)
+	}
+}
@@ -0,0 +1,353 @@
+// Package status summarizes the status kubernetes reports for pods and
+// workloads, e.g. as exported by kubectl get -o yaml, compactly enough
+// to read during an incident.
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/codec"
+	"mantle/pkg/core/pod"
+	"mantle/pkg/core/pod/container"
+	"mantle/pkg/report"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// CrashLoopRestarts is how often a container that isn't ready has to
+// have restarted to be considered crash-looping, even if kubernetes
+// isn't backing off its restarts yet
+const CrashLoopRestarts = 5
+
+const crashLoopBackOff = "CrashLoopBackOff"
+
+// Summary is the status of the pods and workloads of a bundle
+type Summary struct {
+	Pods      []Pod      `json:"pods,omitempty"`
+	Workloads []Workload `json:"workloads,omitempty"`
+}
+
+// Pod is the status of a pod. Ready and Total count its containers,
+// not its init containers.
+type Pod struct {
+	Object         string      `json:"object"`
+	Phase          string      `json:"phase,omitempty"`
+	Reason         string      `json:"reason,omitempty"`
+	QOS            string      `json:"qos,omitempty"`
+	Ready          int         `json:"ready"`
+	Total          int         `json:"total"`
+	InitContainers []Container `json:"init_containers,omitempty"`
+	Containers     []Container `json:"containers,omitempty"`
+	UnmetGates     []string    `json:"unmet_gates,omitempty"`
+}
+
+// Container is the status of a container. State is e.g. running or
+// waiting (CrashLoopBackOff), and LastTermination e.g. OOMKilled
+// (exit 137).
+type Container struct {
+	Name            string `json:"name"`
+	Ready           bool   `json:"ready"`
+	Restarts        int32  `json:"restarts,omitempty"`
+	State           string `json:"state,omitempty"`
+	LastTermination string `json:"last_termination,omitempty"`
+	CrashLooping    bool   `json:"crash_looping,omitempty"`
+}
+
+// Workload is how many of a workload's pods are ready
+type Workload struct {
+	Object  string `json:"object"`
+	Ready   int32  `json:"ready"`
+	Desired int32  `json:"desired"`
+}
+
+// Bundle summarizes the status of every pod in the bundle, kubernetes
+// or mantle, and of every apps/v1 workload. The items of kubernetes
+// lists, as kubectl get writes them for more than one object, are
+// summarized as well.
+func Bundle(b *bundle.Bundle) (*Summary, error) {
+	summary := &Summary{}
+
+	for _, object := range b.Objects {
+		if p, ok := object.Mantle.(*pod.Pod); ok {
+			summary.Pods = append(summary.Pods, PodStatus(object.ID(), p))
+			continue
+		}
+
+		if object.Kube == nil {
+			continue
+		}
+
+		objects := []*bundle.Object{object}
+		if list, ok := object.Kube.(*v1.List); ok {
+			items, err := listItems(list)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", object.Location(), err)
+			}
+			objects = items
+		}
+
+		for _, item := range objects {
+			if err := summary.add(item); err != nil {
+				return nil, fmt.Errorf("%s %s: %v", object.Location(), item.ID(), err)
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+func (s *Summary) add(object *bundle.Object) error {
+	switch obj := object.Kube.(type) {
+	case *v1.Pod:
+		p, err := pod.NewPodFromKubePod(obj)
+		if err != nil {
+			return err
+		}
+		s.Pods = append(s.Pods, PodStatus(object.ID(), p))
+	case *appsv1.Deployment:
+		s.Workloads = append(s.Workloads, Workload{object.ID(), obj.Status.ReadyReplicas, replicas(obj.Spec.Replicas)})
+	case *appsv1.StatefulSet:
+		s.Workloads = append(s.Workloads, Workload{object.ID(), obj.Status.ReadyReplicas, replicas(obj.Spec.Replicas)})
+	case *appsv1.ReplicaSet:
+		s.Workloads = append(s.Workloads, Workload{object.ID(), obj.Status.ReadyReplicas, replicas(obj.Spec.Replicas)})
+	case *appsv1.DaemonSet:
+		s.Workloads = append(s.Workloads, Workload{object.ID(), obj.Status.NumberReady, obj.Status.DesiredNumberScheduled})
+	}
+
+	return nil
+}
+
+// replicas is the number of replicas a workload asks for, which is one
+// if it's unset
+func replicas(n *int32) int32 {
+	if n == nil {
+		return 1
+	}
+	return *n
+}
+
+// listItems parses the items of a kubernetes list, which are kept as
+// raw JSON
+func listItems(list *v1.List) ([]*bundle.Object, error) {
+	var objects []*bundle.Object
+
+	for i, item := range list.Items {
+		obj := map[string]interface{}{}
+		if err := json.Unmarshal(item.Raw, &obj); err != nil {
+			return nil, fmt.Errorf("items[%d]: %v", i, err)
+		}
+
+		kubeObj, err := codec.ParseKubeNativeType(obj)
+		if err != nil {
+			return nil, fmt.Errorf("items[%d]: %v", i, err)
+		}
+		objects = append(objects, newKubeObject(kubeObj))
+	}
+
+	return objects, nil
+}
+
+func newKubeObject(kubeObj runtime.Object) *bundle.Object {
+	object := &bundle.Object{
+		Kind: kubeObj.GetObjectKind().GroupVersionKind().Kind,
+		Kube: kubeObj,
+	}
+	if accessor, err := meta.Accessor(kubeObj); err == nil {
+		object.Name = accessor.GetName()
+		object.Namespace = accessor.GetNamespace()
+	}
+	return object
+}
+
+// Print writes the summary to w in the given format, one of the report
+// formats. The text format has a line per pod and workload, followed by
+// a line per container of the pod:
+//
+//	Pod/pulsar/broker-0: running, 0/1 ready, qos burstable
+//	  broker: not ready, waiting (CrashLoopBackOff), last OOMKilled (exit 137), 7 restarts, crash-looping
+//	StatefulSet/pulsar/bookie: 2/3 ready
+func (s *Summary) Print(w io.Writer, format string) error {
+	switch format {
+	case report.FormatText, "":
+		var lines []string
+		for _, p := range s.Pods {
+			lines = append(lines, p.lines()...)
+		}
+		for _, workload := range s.Workloads {
+			lines = append(lines, fmt.Sprintf("%s: %d/%d ready", workload.Object, workload.Ready, workload.Desired))
+		}
+		for _, line := range lines {
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+		return nil
+	case report.FormatJSON:
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+func (p Pod) lines() []string {
+	parts := []string{p.Phase, p.Reason, fmt.Sprintf("%d/%d ready", p.Ready, p.Total)}
+	if len(p.QOS) > 0 {
+		parts = append(parts, "qos "+p.QOS)
+	}
+	lines := []string{p.Object + ": " + join(parts)}
+
+	for _, c := range p.InitContainers {
+		lines = append(lines, "  init "+c.line())
+	}
+	for _, c := range p.Containers {
+		lines = append(lines, "  "+c.line())
+	}
+	if len(p.UnmetGates) > 0 {
+		lines = append(lines, "  unmet readiness gates: "+strings.Join(p.UnmetGates, ", "))
+	}
+
+	return lines
+}
+
+func (c Container) line() string {
+	ready := "not ready"
+	if c.Ready {
+		ready = "ready"
+	}
+	parts := []string{ready, c.State}
+	if len(c.LastTermination) > 0 {
+		parts = append(parts, "last "+c.LastTermination)
+	}
+	if c.Restarts > 0 {
+		parts = append(parts, fmt.Sprintf("%d restarts", c.Restarts))
+	}
+	if c.CrashLooping {
+		parts = append(parts, "crash-looping")
+	}
+	return c.Name + ": " + join(parts)
+}
+
+// join joins the parts that aren't empty
+func join(parts []string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if len(part) > 0 {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
+}
+
+// PodStatus summarizes the status of the pod, identified by id
+func PodStatus(id string, p *pod.Pod) Pod {
+	status := Pod{
+		Object: id,
+		Phase:  text(p.Phase),
+		Reason: p.Reason,
+		QOS:    text(p.QOS),
+		Total:  len(p.Containers),
+	}
+	if p.Phase == pod.PodPhaseNone {
+		status.Phase = ""
+	}
+	if p.QOS == pod.PodQOSClassNone {
+		status.QOS = ""
+	}
+
+	for _, c := range p.InitContainers {
+		status.InitContainers = append(status.InitContainers, containerStatus(c))
+	}
+	for _, c := range p.Containers {
+		cs := containerStatus(c)
+		if cs.Ready {
+			status.Ready++
+		}
+		status.Containers = append(status.Containers, cs)
+	}
+
+	// A readiness gate is met once the pod has a true condition of its type.
+	for _, gate := range p.Gates {
+		met := false
+		for _, condition := range p.Conditions {
+			if condition.Type == gate && condition.Status == pod.ConditionStatusTrue {
+				met = true
+			}
+		}
+		if !met {
+			status.UnmetGates = append(status.UnmetGates, string(gate))
+		}
+	}
+
+	return status
+}
+
+func containerStatus(c container.Container) Container {
+	status := Container{
+		Name:     c.Name,
+		Ready:    c.Ready,
+		Restarts: c.Restarts,
+		State:    formatState(c.CurrentState),
+	}
+
+	if c.LastState != nil && c.LastState.Terminated != nil {
+		status.LastTermination = formatTermination(c.LastState.Terminated)
+	}
+
+	backingOff := c.CurrentState != nil && c.CurrentState.Waiting != nil && c.CurrentState.Waiting.Reason == crashLoopBackOff
+	status.CrashLooping = backingOff || (!c.Ready && c.Restarts >= CrashLoopRestarts)
+
+	return status
+}
+
+func formatState(state *container.ContainerState) string {
+	switch {
+	case state == nil:
+		return ""
+	case state.Waiting != nil:
+		if len(state.Waiting.Reason) > 0 {
+			return fmt.Sprintf("waiting (%s)", state.Waiting.Reason)
+		}
+		return "waiting"
+	case state.Running != nil:
+		return "running"
+	case state.Terminated != nil:
+		return "terminated: " + formatTermination(state.Terminated)
+	default:
+		return ""
+	}
+}
+
+// formatTermination writes the reason and exit code of a termination,
+// e.g. OOMKilled (exit 137)
+func formatTermination(terminated *container.ContainerStateTerminated) string {
+	exit := fmt.Sprintf("exit %d", terminated.ExitCode)
+	if terminated.Signal != 0 {
+		exit = fmt.Sprintf("%s, signal %d", exit, terminated.Signal)
+	}
+
+	if len(terminated.Reason) == 0 {
+		return exit
+	}
+	return fmt.Sprintf("%s (%s)", terminated.Reason, exit)
+}
+
+// text returns the name an enum is written as
+func text(value interface{ MarshalText() ([]byte, error) }) string {
+	data, err := value.MarshalText()
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,134 @@
+package status
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"mantle/pkg/bundle"
+	"mantle/pkg/core/pod/container"
+)
+
+// Pods and a workload as kubectl get -o yaml exports them
+const testList = `
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Pod
+  metadata:
+    name: broker-0
+    namespace: pulsar
+  spec:
+    initContainers:
+    - name: wait-zookeeper
+      image: apachepulsar/pulsar:2.2.1
+    containers:
+    - name: broker
+      image: apachepulsar/pulsar:2.2.1
+    - name: exporter
+      image: prom/exporter:1.0
+    readinessGates:
+    - conditionType: target-health.elbv2.k8s.aws/pulsar-broker
+  status:
+    phase: Running
+    qosClass: Burstable
+    conditions:
+    - type: target-health.elbv2.k8s.aws/pulsar-broker
+      status: "False"
+    initContainerStatuses:
+    - name: wait-zookeeper
+      image: apachepulsar/pulsar:2.2.1
+      imageID: docker-pullable://apachepulsar/pulsar@sha256:0123
+      containerID: docker://wait
+      ready: true
+      restartCount: 0
+      state:
+        terminated:
+          exitCode: 0
+          reason: Completed
+    containerStatuses:
+    - name: broker
+      image: apachepulsar/pulsar:2.2.1
+      imageID: docker-pullable://apachepulsar/pulsar@sha256:0123
+      containerID: docker://broker
+      ready: false
+      restartCount: 7
+      state:
+        waiting:
+          reason: CrashLoopBackOff
+      lastState:
+        terminated:
+          exitCode: 137
+          reason: OOMKilled
+    - name: exporter
+      image: prom/exporter:1.0
+      imageID: docker-pullable://prom/exporter@sha256:4567
+      containerID: docker://exporter
+      ready: true
+      restartCount: 0
+      state:
+        running: {}
+- apiVersion: apps/v1
+  kind: StatefulSet
+  metadata:
+    name: bookie
+    namespace: pulsar
+  spec:
+    replicas: 3
+    template:
+      spec:
+        containers:
+        - name: bookie
+          image: apachepulsar/pulsar:2.2.1
+  status:
+    replicas: 3
+    readyReplicas: 2
+`
+
+func TestBundle(t *testing.T) {
+	objects, err := bundle.Read("pulsar.yaml", strings.NewReader(testList))
+	if err != nil {
+		t.Fatalf("unexpected error reading bundle: %v", err)
+	}
+
+	summary, err := Bundle(&bundle.Bundle{Objects: objects})
+	if err != nil {
+		t.Fatalf("unexpected error summarizing bundle: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := summary.Print(buf, "text"); err != nil {
+		t.Fatalf("unexpected error printing summary: %v", err)
+	}
+
+	expected := `Pod/pulsar/broker-0: running, 1/2 ready, qos burstable
+  init wait-zookeeper: ready, terminated: Completed (exit 0)
+  broker: not ready, waiting (CrashLoopBackOff), last OOMKilled (exit 137), 7 restarts, crash-looping
+  exporter: ready, running
+  unmet readiness gates: target-health.elbv2.k8s.aws/pulsar-broker
+StatefulSet/pulsar/bookie: 2/3 ready
+`
+	if result := buf.String(); result != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, result)
+	}
+}
+
+func TestCrashLooping(t *testing.T) {
+	backingOff := &container.ContainerState{Waiting: &container.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+	testCases := []struct {
+		container container.Container
+		expected  bool
+	}{
+		{container.Container{CurrentState: backingOff, Restarts: 1}, true},
+		{container.Container{Restarts: CrashLoopRestarts}, true},
+		{container.Container{Ready: true, Restarts: CrashLoopRestarts}, false},
+		{container.Container{Restarts: 1}, false},
+	}
+
+	for i, testCase := range testCases {
+		if result := containerStatus(testCase.container).CrashLooping; result != testCase.expected {
+			t.Errorf("case %d: expected %v, got %v", i, testCase.expected, result)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package enum
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/koki/json"
+	serrors "github.com/koki/structurederrors"
+)
+
+// Names lists the string forms of an int enum, indexed by value.
+// Enums are written as their names, and read from their names
+// (ignoring case) or, for files written by older versions of
+// mantle, from their integer values. The methods that do this for an
+// enum type are generated by internal/enumgen.
+type Names []string
+
+// Text returns the name of value
+func (n Names) Text(value int) ([]byte, error) {
+	if value < 0 || value >= len(n) {
+		return nil, serrors.InvalidValueErrorf(value, "expected one of %s", n)
+	}
+	return []byte(n[value]), nil
+}
+
+// ParseText returns the value of a name
+func (n Names) ParseText(text []byte) (int, error) {
+	str := strings.TrimSpace(string(text))
+	for value, name := range n {
+		if strings.EqualFold(name, str) {
+			return value, nil
+		}
+	}
+
+	if value, err := strconv.Atoi(str); err == nil && value >= 0 && value < len(n) {
+		return value, nil
+	}
+
+	return 0, serrors.InvalidValueErrorf(str, "expected one of %s", n)
+}
+
+// ParseJSON returns the value of a JSON name or integer
+func (n Names) ParseJSON(data []byte) (int, error) {
+	var value int
+	if err := json.Unmarshal(data, &value); err == nil {
+		if value < 0 || value >= len(n) {
+			return 0, serrors.InvalidValueErrorf(value, "expected one of %s", n)
+		}
+		return value, nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return 0, serrors.InvalidValueErrorf(string(data), "expected one of %s", n)
+	}
+
+	return n.ParseText([]byte(str))
+}
+
+func (n Names) String() string {
+	return strings.Join(n, ", ")
+}
@@ -0,0 +1,241 @@
+// Package extra carries the kubernetes fields mantle doesn't model
+// through a conversion to mantle and back, so they aren't lost.
+//
+// Fields unknown to the kubernetes API mantle is built against are
+// dropped when the kubernetes object is parsed, before mantle sees
+// them, so they can't be carried.
+package extra
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Extra is a fragment of a kubernetes object holding the fields mantle
+// doesn't model, e.g.
+// {"metadata": {"finalizers": ["pulsar.apache.org/cleanup"]}}.
+// It's merged into the object mantle generates, adding the fields and
+// list items the object doesn't have. What mantle generates is never
+// overwritten, so edits to the mantle fields aren't lost.
+type Extra map[string]interface{}
+
+// Unmapped returns the fields of the kubernetes object original that
+// mantle has no field for: those that aren't in generated, the object
+// mantle generated from its conversion of original. Fields mantle
+// models are left to mantle even if their values differ, so they can
+// be edited. List items are compared with the item of generated that
+// has the same name, e.g. volumes and env vars, or else with the item
+// at the same index, e.g. tolerations. Named items missing from
+// generated are kept whole, e.g. volumes of a type mantle doesn't
+// support. Top-level keys in skip aren't compared. It returns nil if
+// nothing is missing.
+func Unmapped(original, generated interface{}, skip ...string) (Extra, error) {
+	before, err := tree(original)
+	if err != nil {
+		return nil, err
+	}
+	after, err := tree(generated)
+	if err != nil {
+		return nil, err
+	}
+
+	if before, ok := before.(map[string]interface{}); ok {
+		for _, key := range skip {
+			delete(before, key)
+		}
+	}
+
+	fragment, _ := unmapped(before, after).(map[string]interface{})
+	if len(fragment) == 0 {
+		return nil, nil
+	}
+	return Extra(fragment), nil
+}
+
+// unmapped returns what of before isn't in after, which is nil if after
+// has all of before. What's missing from the items of a list is written
+// at their places, with nil for the items that aren't missing anything.
+// The fragment of a named item keeps its name, so it can be found again;
+// if only named items are missing something, just those are listed.
+func unmapped(before, after interface{}) interface{} {
+	if after == nil {
+		return before
+	}
+
+	switch b := before.(type) {
+	case map[string]interface{}:
+		a, _ := after.(map[string]interface{})
+		fragment := map[string]interface{}{}
+		for key, value := range b {
+			if value == nil {
+				continue
+			}
+			if missing := unmapped(value, a[key]); missing != nil {
+				fragment[key] = missing
+			}
+		}
+		if len(fragment) == 0 {
+			return nil
+		}
+		return fragment
+
+	case []interface{}:
+		a, _ := after.([]interface{})
+		items := make([]interface{}, len(b))
+		var named []interface{}
+		last, unnamed := -1, false
+		for i, item := range b {
+			missing := unmapped(item, matchingItem(a, i, item))
+			if missing == nil {
+				continue
+			}
+			if name, ok := itemName(item); ok {
+				missing.(map[string]interface{})["name"] = name
+				named = append(named, missing)
+			} else {
+				unnamed = true
+			}
+			items[i] = missing
+			last = i
+		}
+		switch {
+		case last < 0:
+			return nil
+		case !unnamed:
+			// Named items are found by their names, so they
+			// don't need to keep their places.
+			return named
+		default:
+			return items[:last+1]
+		}
+
+	default:
+		return nil
+	}
+}
+
+// matchingItem returns the item of list that item corresponds to: the
+// one with the same name if item is named, or else the one at index i
+func matchingItem(list []interface{}, i int, item interface{}) interface{} {
+	if name, ok := itemName(item); ok {
+		if j := indexOfItem(list, name); j >= 0 {
+			return list[j]
+		}
+		return nil
+	}
+	if i < len(list) {
+		return list[i]
+	}
+	return nil
+}
+
+// itemName returns the name of a list item, e.g. of a volume
+func itemName(item interface{}) (string, bool) {
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	name, ok := m["name"].(string)
+	return name, ok
+}
+
+// Apply merges the fragment into obj, a pointer to a kubernetes object
+func (e Extra) Apply(obj interface{}) error {
+	if len(e) == 0 {
+		return nil
+	}
+
+	target, err := tree(obj)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(merge(target, map[string]interface{}(e)))
+	if err != nil {
+		return err
+	}
+
+	// Unmarshal into a fresh value rather than over the old contents.
+	v := reflect.ValueOf(obj).Elem()
+	fresh := reflect.New(v.Type())
+	if err := json.Unmarshal(data, fresh.Interface()); err != nil {
+		return err
+	}
+	v.Set(fresh.Elem())
+	return nil
+}
+
+// merge adds the fields and list items of patch that target doesn't
+// have. Fields target already has come from mantle, and are kept. A
+// named item of patch is merged into the item of target with its name,
+// or added if target has none. Other items are merged into the item at
+// their index, and dropped if target has none, since it was removed
+// in mantle.
+func merge(target, patch interface{}) interface{} {
+	if target == nil {
+		return patch
+	}
+
+	switch p := patch.(type) {
+	case map[string]interface{}:
+		t, ok := target.(map[string]interface{})
+		if !ok {
+			return target
+		}
+		for key, value := range p {
+			t[key] = merge(t[key], value)
+		}
+		return t
+
+	case []interface{}:
+		t, ok := target.([]interface{})
+		if !ok {
+			return target
+		}
+		for i, item := range p {
+			if item == nil {
+				continue
+			}
+
+			name, named := itemName(item)
+			switch {
+			case named:
+				j := indexOfItem(t, name)
+				if j < 0 {
+					t = append(t, item)
+					continue
+				}
+				t[j] = merge(t[j], item)
+			case i < len(t):
+				t[i] = merge(t[i], item)
+			}
+		}
+		return t
+
+	default:
+		return target
+	}
+}
+
+// indexOfItem returns the index of the item of list with the name, or -1
+func indexOfItem(list []interface{}, name string) int {
+	for i, item := range list {
+		if n, ok := itemName(item); ok && n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// tree converts a kubernetes object into maps, lists and scalars, as
+// it's written in a manifest
+func tree(obj interface{}) (interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var t interface{}
+	err = json.Unmarshal(data, &t)
+	return t, err
+}
@@ -0,0 +1,126 @@
+package extra
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUnmapped(t *testing.T) {
+	grace := int64(30)
+	seconds := int64(60)
+	original := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "broker", DeletionGracePeriodSeconds: &grace},
+		Spec: v1.PodSpec{
+			SchedulerName: "custom",
+			Tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpExists},
+				{Key: "spot", Operator: v1.TolerationOpExists, TolerationSeconds: &seconds},
+			},
+			Volumes: []v1.Volume{
+				{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{Medium: v1.StorageMediumMemory}}},
+				{Name: "logs"},
+				{Name: "secrets"},
+			},
+		},
+	}
+	// Stands in for what mantle generates if it doesn't model a
+	// toleration's seconds or an emptyDir's medium.
+	generated := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "broker"},
+		Spec: v1.PodSpec{
+			SchedulerName: "default-scheduler",
+			Tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual},
+				{Key: "spot", Operator: v1.TolerationOpExists},
+			},
+			Volumes: []v1.Volume{
+				{Name: "logs"},
+				{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+
+	fragment, err := Unmapped(original, generated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Fields mantle models are left out even if they differ. Tolerations
+	// are matched by index and volumes by name, and the volume mantle
+	// didn't generate is kept whole.
+	expected := Extra{
+		"metadata": map[string]interface{}{"deletionGracePeriodSeconds": float64(30)},
+		"spec": map[string]interface{}{
+			"tolerations": []interface{}{
+				nil,
+				map[string]interface{}{"tolerationSeconds": float64(60)},
+			},
+			"volumes": []interface{}{
+				map[string]interface{}{"name": "data", "emptyDir": map[string]interface{}{"medium": "Memory"}},
+				map[string]interface{}{"name": "secrets"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(fragment, expected) {
+		t.Errorf("expected %v, got %v", expected, fragment)
+	}
+}
+
+func TestApply(t *testing.T) {
+	fragment := Extra{
+		"metadata": map[string]interface{}{
+			"finalizers": []interface{}{"pulsar.apache.org/cleanup"},
+		},
+		"spec": map[string]interface{}{
+			"schedulerName": "custom",
+			"tolerations": []interface{}{
+				nil,
+				map[string]interface{}{"tolerationSeconds": float64(60)},
+				map[string]interface{}{"tolerationSeconds": float64(30)},
+			},
+			"volumes": []interface{}{
+				map[string]interface{}{"name": "data", "emptyDir": map[string]interface{}{"medium": "Memory"}},
+				map[string]interface{}{"name": "secrets"},
+			},
+		},
+	}
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "broker"},
+		Spec: v1.PodSpec{
+			SchedulerName: "edited",
+			Tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpExists},
+				{Key: "spot", Operator: v1.TolerationOpExists},
+			},
+			Volumes: []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}},
+		},
+	}
+	if err := fragment.Apply(pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// What mantle generated is kept; the fragment only adds to it. The
+	// third toleration was removed in mantle, so it isn't added back.
+	seconds := int64(60)
+	expected := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "broker", Finalizers: []string{"pulsar.apache.org/cleanup"}},
+		Spec: v1.PodSpec{
+			SchedulerName: "edited",
+			Tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpExists},
+				{Key: "spot", Operator: v1.TolerationOpExists, TolerationSeconds: &seconds},
+			},
+			Volumes: []v1.Volume{
+				{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{Medium: v1.StorageMediumMemory}}},
+				{Name: "secrets"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(pod, expected) {
+		t.Errorf("expected %+v, got %+v", expected, pod)
+	}
+}
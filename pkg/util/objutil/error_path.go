@@ -0,0 +1,70 @@
+package objutil
+
+import (
+	"fmt"
+	"strings"
+
+	serrors "github.com/koki/structurederrors"
+)
+
+// pathContextPrefix marks an error context as a path into the
+// converted document. It's the same form the json decoder uses.
+const pathContextPrefix = "$"
+
+// ErrorAtPath adds a path into the converted document to err's context,
+// e.g. ErrorAtPath(err, "containers", 1, "cpu") for $.containers.1.cpu
+// Paths added by callers are joined with the paths added by callees.
+func ErrorAtPath(err error, path ...interface{}) error {
+	segments := make([]string, len(path))
+	for i, segment := range path {
+		segments[i] = fmt.Sprint(segment)
+	}
+
+	return serrors.ContextualizeErrorf(err, "%s", pathContextPrefix+"."+strings.Join(segments, "."))
+}
+
+// ErrorPath returns the path into the converted document
+// that err occurred at, e.g. containers.1.cpu
+func ErrorPath(err error) (string, bool) {
+	errWithContext, ok := err.(*serrors.ErrorWithContext)
+	if !ok {
+		return "", false
+	}
+
+	var segments []string
+	for _, context := range serrors.ReversedStringsList(errWithContext.Context) {
+		if context == pathContextPrefix {
+			continue
+		}
+		if strings.HasPrefix(context, pathContextPrefix+".") {
+			segments = append(segments, strings.TrimPrefix(context, pathContextPrefix+"."))
+		}
+	}
+
+	if len(segments) == 0 {
+		return "", false
+	}
+
+	return strings.Join(segments, "."), true
+}
+
+// WithoutErrorPath returns err without the paths added by ErrorAtPath,
+// for messages that give the position of the path instead
+func WithoutErrorPath(err error) error {
+	errWithContext, ok := err.(*serrors.ErrorWithContext)
+	if !ok {
+		return err
+	}
+
+	var context []string
+	for _, c := range errWithContext.Context {
+		if c != pathContextPrefix && !strings.HasPrefix(c, pathContextPrefix+".") {
+			context = append(context, c)
+		}
+	}
+
+	if len(context) == 0 {
+		return errWithContext.BaseError
+	}
+	return &serrors.ErrorWithContext{BaseError: errWithContext.BaseError, Context: context}
+}
@@ -1,6 +1,7 @@
 package objutil
 
 import (
+	"sort"
 	"strconv"
 
 	serrors "github.com/koki/structurederrors"
@@ -41,3 +42,46 @@ func AtPathIn(obj interface{}, path []string) (interface{}, error) {
 		return nil, serrors.InvalidValueErrorf(key, "can only index into slice or map")
 	}
 }
+
+// Wildcard matches every element of a slice or map in a path
+const Wildcard = "*"
+
+// ExpandPathIn replaces the wildcards in path with the indices and keys
+// they match in obj, returning one concrete path per match. Map keys are
+// expanded in sorted order. Elements of the path after the last wildcard
+// are kept as-is, so the concrete paths may not exist in obj; use
+// AtPathIn to read them.
+func ExpandPathIn(obj interface{}, path []string) [][]string {
+	for n, key := range path {
+		if key != Wildcard {
+			continue
+		}
+
+		parent, err := AtPathIn(obj, path[:n])
+		if err != nil {
+			return nil
+		}
+
+		var keys []string
+		switch parent := parent.(type) {
+		case []interface{}:
+			for i := range parent {
+				keys = append(keys, strconv.Itoa(i))
+			}
+		case map[string]interface{}:
+			for k := range parent {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+		}
+
+		var paths [][]string
+		for _, k := range keys {
+			concrete := append(append(append([]string{}, path[:n]...), k), path[n+1:]...)
+			paths = append(paths, ExpandPathIn(obj, concrete)...)
+		}
+		return paths
+	}
+
+	return [][]string{path}
+}
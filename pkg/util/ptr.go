@@ -8,6 +8,10 @@ func Int32Ptr(i int32) *int32 {
 	return &i
 }
 
+func Int64Ptr(i int64) *int64 {
+	return &i
+}
+
 func IntOrStringPtr(i intstr.IntOrString) *intstr.IntOrString {
 	return &i
 }
@@ -0,0 +1,146 @@
+package validation
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	kubevalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Prefix returns the errors with their field paths nested under fldPath.
+// Each mantle type validates itself with paths relative to the type,
+// so its parent prefixes them with the field the type is held in.
+func Prefix(fldPath *field.Path, errs field.ErrorList) field.ErrorList {
+	if fldPath == nil || len(errs) == 0 {
+		return errs
+	}
+
+	prefixed := make(field.ErrorList, 0, len(errs))
+	for _, err := range errs {
+		e := *err
+		switch {
+		case len(e.Field) == 0:
+			e.Field = fldPath.String()
+		case strings.HasPrefix(e.Field, "["):
+			e.Field = fldPath.String() + e.Field
+		default:
+			e.Field = fldPath.String() + "." + e.Field
+		}
+		prefixed = append(prefixed, &e)
+	}
+
+	return prefixed
+}
+
+// Index is the relative path of the i-th item of a list,
+// for types that validate a list of items
+func Index(i int) *field.Path {
+	return (*field.Path)(nil).Index(i)
+}
+
+// Key is the relative path of a map entry,
+// for types that validate a map
+func Key(key string) *field.Path {
+	return (*field.Path)(nil).Key(key)
+}
+
+// Required reports an empty value
+func Required(fldPath *field.Path, value string) field.ErrorList {
+	if len(value) == 0 {
+		return field.ErrorList{field.Required(fldPath, "")}
+	}
+	return nil
+}
+
+// DNS1123Label checks that a non-empty value is a DNS-1123 label, e.g. a container name
+func DNS1123Label(fldPath *field.Path, value string) field.ErrorList {
+	return messages(fldPath, value, kubevalidation.IsDNS1123Label)
+}
+
+// DNS1123Subdomain checks that a non-empty value is a DNS-1123 subdomain, e.g. an object name
+func DNS1123Subdomain(fldPath *field.Path, value string) field.ErrorList {
+	return messages(fldPath, value, kubevalidation.IsDNS1123Subdomain)
+}
+
+// QualifiedName checks that a non-empty value is a qualified name, e.g. a label key
+func QualifiedName(fldPath *field.Path, value string) field.ErrorList {
+	return messages(fldPath, value, kubevalidation.IsQualifiedName)
+}
+
+// Labels checks the syntax of label keys and values
+func Labels(fldPath *field.Path, labels map[string]string) field.ErrorList {
+	var errs field.ErrorList
+	for _, key := range sortedKeys(labels) {
+		errs = append(errs, metavalidation.ValidateLabelName(key, fldPath)...)
+		for _, msg := range kubevalidation.IsValidLabelValue(labels[key]) {
+			errs = append(errs, field.Invalid(fldPath.Key(key), labels[key], msg))
+		}
+	}
+	return errs
+}
+
+// Annotations checks the syntax of annotation keys
+func Annotations(fldPath *field.Path, annotations map[string]string) field.ErrorList {
+	var errs field.ErrorList
+	for _, key := range sortedKeys(annotations) {
+		for _, msg := range kubevalidation.IsQualifiedName(strings.ToLower(key)) {
+			errs = append(errs, field.Invalid(fldPath, key, msg))
+		}
+	}
+	return errs
+}
+
+// Port checks that a non-empty value is a port number (1-65535) or,
+// if names are allowed, an IANA service name
+func Port(fldPath *field.Path, value string, allowName bool) field.ErrorList {
+	if len(value) == 0 {
+		return nil
+	}
+
+	port, err := strconv.Atoi(value)
+	if err != nil {
+		if !allowName {
+			return field.ErrorList{field.Invalid(fldPath, value, "must be a port number")}
+		}
+		return messages(fldPath, value, kubevalidation.IsValidPortName)
+	}
+
+	var errs field.ErrorList
+	for _, msg := range kubevalidation.IsValidPortNum(port) {
+		errs = append(errs, field.Invalid(fldPath, value, msg))
+	}
+	return errs
+}
+
+// IP checks that a non-empty value is an IP address
+func IP(fldPath *field.Path, value string) field.ErrorList {
+	if len(value) > 0 && net.ParseIP(value) == nil {
+		return field.ErrorList{field.Invalid(fldPath, value, "must be a valid IP address")}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func messages(fldPath *field.Path, value string, check func(string) []string) field.ErrorList {
+	if len(value) == 0 {
+		return nil
+	}
+
+	var errs field.ErrorList
+	for _, msg := range check(value) {
+		errs = append(errs, field.Invalid(fldPath, value, msg))
+	}
+	return errs
+}